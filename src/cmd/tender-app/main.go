@@ -2,28 +2,20 @@ package main
 
 import (
 	"fmt"
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"net/http"
-	"tender-app-backend/src/internal/http-server/handlers/create/bidcreate"
-	"tender-app-backend/src/internal/http-server/handlers/create/tndcreate"
-	"tender-app-backend/src/internal/http-server/handlers/edit/bidedit"
-	"tender-app-backend/src/internal/http-server/handlers/edit/tndedit"
-	"tender-app-backend/src/internal/http-server/handlers/get-list/all/bidget"
-	"tender-app-backend/src/internal/http-server/handlers/get-list/all/tndget"
-	"tender-app-backend/src/internal/http-server/handlers/get-list/status/bidstatus"
-	"tender-app-backend/src/internal/http-server/handlers/get-list/status/tndstatus"
-	"tender-app-backend/src/internal/http-server/handlers/get-list/user/userbidget"
-	"tender-app-backend/src/internal/http-server/handlers/get-list/user/usertndget"
-	"tender-app-backend/src/internal/http-server/handlers/ping"
-	"tender-app-backend/src/internal/http-server/handlers/rollback/bidrollback"
-	"tender-app-backend/src/internal/http-server/handlers/rollback/tndrollback"
-	"tender-app-backend/src/internal/http-server/handlers/submit"
+	"tender-app-backend/src/internal/http-server/openapi"
 	"tender-app-backend/src/internal/lib/logger/sl"
+	"tender-app-backend/src/internal/scheduler"
+	"tender-app-backend/src/internal/storage"
+	"tender-app-backend/src/internal/storage/memory"
 	"tender-app-backend/src/internal/storage/postgres"
 
+	"context"
 	"log/slog"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
 	"tender-app-backend/src/internal/config"
 )
 
@@ -32,57 +24,89 @@ func main() {
 
 	log := setupLogger()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, log, os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		os.Exit(runToken(cfg, log, os.Args[2:]))
+	}
+
+	os.Exit(runServer(cfg, log))
+}
+
+// runServer serves the API until SIGINT or SIGTERM and returns the process
+// exit code.
+func runServer(cfg *config.Config, log *slog.Logger) int {
 	log.Info("starting tender-app")
 	log.Debug("debug logging enabled")
 
 	// TODO: fix env variables
 
-	storage, err := postgres.New(cfg)
-	if err != nil {
-		log.Error("failed to init database connection", sl.Err(err))
-		os.Exit(1)
+	if cfg.TokenKey == "" {
+		log.Error("AUTH_TOKEN_KEY is not set")
+		return 1
 	}
 
-	router := chi.NewRouter()
+	// queries is the base context of every request. It is cancelled when
+	// requests are not drained in time, storage calls still in flight
+	// are aborted with it.
+	queries, cancelQueries := context.WithCancel(context.Background())
+	defer cancelQueries()
 
-	router.Use(middleware.RequestID)
-	router.Use(middleware.Logger)
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.URLFormat)
+	storage, err := setupStorage(queries, cfg)
+	if err != nil {
+		log.Error("failed to init storage", slog.String("driver", cfg.Driver), sl.Err(err))
+		return 1
+	}
+	defer func() {
+		if err := storage.Close(); err != nil {
+			log.Error("failed to close storage", sl.Err(err))
+		}
+	}()
 
-	router.Get("/api/ping", ping.New(log))
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Error("failed to load api spec", sl.Err(err))
+		return 1
+	}
 
-	router.Get("/api/tenders", tndget.New(log, storage))
-	router.Post("/api/tenders/new", tndcreate.New(log, storage))
-	router.Get("/api/tenders/my", usertndget.New(log, storage))
-	router.Get("/api/tenders/status", tndstatus.New(log, storage))
-	router.Patch("/api/tenders/{tenderId}/edit", tndedit.New(log, storage))
-	router.Put("/api/tenders/{tenderId}/rollback/{version}", tndrollback.New(log, storage))
+	router := newRouter(log, cfg, spec, storage)
 
-	router.Post("/api/bids/new", bidcreate.New(log, storage))
-	router.Get("/api/bids/my", userbidget.New(log, storage))
-	router.Get("/api/bids/{tenderId}/list", bidget.New(log, storage))
-	router.Get("/api/bids/status", bidstatus.New(log, storage))
-	router.Patch("/api/bids/{bidId}/edit", bidedit.New(log, storage))
-	router.Put("/api/bids/{bidId}/rollback/{version}", bidrollback.New(log, storage))
-	router.Put("/api/bids/{bidId}/submit_decision", submit.New(log, storage))
+	ln, err := net.Listen("tcp", cfg.ServerAddress)
+	if err != nil {
+		log.Error("failed to start server", sl.Err(err))
+		return 1
+	}
 
 	log.Info("starting server", slog.String("address", cfg.ServerAddress))
 
 	srv := &http.Server{
-		Addr:         cfg.ServerAddress,
 		Handler:      router,
 		ReadTimeout:  cfg.Timeout,
 		WriteTimeout: cfg.Timeout,
 		IdleTimeout:  cfg.IdleTimeout,
+		BaseContext:  func(net.Listener) context.Context { return queries },
 	}
 
-	if err := srv.ListenAndServe(); err != nil {
-		fmt.Println(err)
-		log.Error("failed to start server")
-	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The scheduler stops with the server, before storage is closed.
+	schedulerCtx, stopScheduler := context.WithCancel(queries)
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		scheduler.Run(schedulerCtx, log, storage, cfg.DeadlineInterval)
+	}()
+
+	code := serve(ctx, log, srv, ln, cfg.ShutdownTimeout, cancelQueries)
 
-	log.Error("server stopped")
+	stopScheduler()
+	<-schedulerDone
+
+	log.Info("server stopped", slog.Int("code", code))
+
+	return code
 }
 
 func setupLogger() *slog.Logger {
@@ -92,3 +116,21 @@ func setupLogger() *slog.Logger {
 
 	return log
 }
+
+// setupStorage opens the configured backend. ctx bounds loading the seed.
+func setupStorage(ctx context.Context, cfg *config.Config) (storage.Storage, error) {
+	switch cfg.Driver {
+	case "postgres":
+		return postgres.New(cfg)
+	case "memory":
+		s := memory.New()
+		if cfg.MemorySeedFile != "" {
+			if err := s.LoadSeed(ctx, cfg.MemorySeedFile); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"tender-app-backend/src/internal/config"
+	"tender-app-backend/src/internal/lib/logger/sl"
+	"tender-app-backend/src/internal/storage/postgres"
+	"tender-app-backend/src/internal/storage/postgres/migrate"
+)
+
+const migrateUsage = "usage: tender-app migrate up|down|status"
+
+// runMigrate handles the migrate subcommand and returns the process exit code.
+func runMigrate(cfg *config.Config, log *slog.Logger, args []string) int {
+	if len(args) != 1 {
+		fmt.Println(migrateUsage)
+		return 2
+	}
+
+	db, err := postgres.Connect(cfg)
+	if err != nil {
+		log.Error("failed to init database connection", sl.Err(err))
+		return 1
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrate.Up(db)
+		for _, m := range applied {
+			log.Info("migration applied", slog.Int("version", m.Version), slog.String("name", m.Name))
+		}
+		if err != nil {
+			log.Error("failed to apply migrations", sl.Err(err))
+			return 1
+		}
+		if len(applied) == 0 {
+			log.Info("no pending migrations")
+		}
+	case "down":
+		m, err := migrate.Down(db)
+		if err != nil {
+			if errors.Is(err, migrate.ErrNoMigrations) {
+				log.Info("no applied migrations")
+				return 0
+			}
+			log.Error("failed to revert migration", sl.Err(err))
+			return 1
+		}
+		log.Info("migration reverted", slog.Int("version", m.Version), slog.String("name", m.Name))
+	case "status":
+		statuses, err := migrate.GetStatus(db)
+		if err != nil {
+			log.Error("failed to get migrations status", sl.Err(err))
+			return 1
+		}
+		for _, st := range statuses {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", st.Version, st.Name, applied)
+		}
+	default:
+		fmt.Println(migrateUsage)
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal/config"
+	"tender-app-backend/src/internal/http-server/handlers/create/bidcreate"
+	"tender-app-backend/src/internal/http-server/handlers/create/empcreate"
+	"tender-app-backend/src/internal/http-server/handlers/create/orgcreate"
+	"tender-app-backend/src/internal/http-server/handlers/create/tndcreate"
+	"tender-app-backend/src/internal/http-server/handlers/delete/empdelete"
+	"tender-app-backend/src/internal/http-server/handlers/delete/orgdelete"
+	"tender-app-backend/src/internal/http-server/handlers/edit/bidedit"
+	"tender-app-backend/src/internal/http-server/handlers/edit/empedit"
+	"tender-app-backend/src/internal/http-server/handlers/edit/orgedit"
+	"tender-app-backend/src/internal/http-server/handlers/edit/tndedit"
+	"tender-app-backend/src/internal/http-server/handlers/feedback/bidfeedback"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/all/bidget"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/all/empsget"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/all/orgsget"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/all/tndget"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/audit/auditget"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/review/reviewget"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/status/bidstatus"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/status/tndstatus"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/user/userbidget"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/user/usertndget"
+	"tender-app-backend/src/internal/http-server/handlers/get/empget"
+	"tender-app-backend/src/internal/http-server/handlers/get/orgget"
+	"tender-app-backend/src/internal/http-server/handlers/ping"
+	"tender-app-backend/src/internal/http-server/handlers/responsible/respadd"
+	"tender-app-backend/src/internal/http-server/handlers/responsible/respget"
+	"tender-app-backend/src/internal/http-server/handlers/responsible/respremove"
+	"tender-app-backend/src/internal/http-server/handlers/rollback/bidrollback"
+	"tender-app-backend/src/internal/http-server/handlers/rollback/tndrollback"
+	"tender-app-backend/src/internal/http-server/handlers/status/bidstatusget"
+	"tender-app-backend/src/internal/http-server/handlers/status/bidstatusput"
+	"tender-app-backend/src/internal/http-server/handlers/status/tndstatusget"
+	"tender-app-backend/src/internal/http-server/handlers/status/tndstatusput"
+	"tender-app-backend/src/internal/http-server/handlers/submit"
+	"tender-app-backend/src/internal/http-server/handlers/versions/biddiff"
+	"tender-app-backend/src/internal/http-server/handlers/versions/bidversions"
+	"tender-app-backend/src/internal/http-server/handlers/versions/tnddiff"
+	"tender-app-backend/src/internal/http-server/handlers/versions/tndversions"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/http-server/middleware/timeout"
+	"tender-app-backend/src/internal/http-server/middleware/validate"
+	"tender-app-backend/src/internal/http-server/openapi"
+	"tender-app-backend/src/internal/lib/token"
+	"tender-app-backend/src/internal/storage"
+)
+
+// newRouter mounts every endpoint behind the middleware chain requests go
+// through: request ids, logging, recovery, the timeout, spec validation
+// and authentication.
+func newRouter(log *slog.Logger, cfg *config.Config, spec *openapi.Spec, storage storage.Storage) http.Handler {
+	router := chi.NewRouter()
+
+	router.Use(middleware.RequestID)
+	router.Use(middleware.Logger)
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.URLFormat)
+	router.Use(timeout.New(cfg.Timeout))
+	router.Use(validate.New(log, spec, cfg.DevMode))
+	router.Use(auth.New(log, token.New(cfg.TokenKey, cfg.TokenTTL), storage))
+
+	router.Get("/api/ping", ping.New(log))
+
+	router.Get("/api/tenders", tndget.New(log, storage))
+	router.Post("/api/tenders/new", tndcreate.New(log, storage))
+	router.Get("/api/tenders/my", usertndget.New(log, storage))
+	router.Get("/api/tenders/status", tndstatus.New(log, storage))
+	router.Get("/api/tenders/{tenderId}/status", tndstatusget.New(log, storage))
+	router.Put("/api/tenders/{tenderId}/status", tndstatusput.New(log, storage))
+	router.Patch("/api/tenders/{tenderId}/edit", tndedit.New(log, storage))
+	router.Put("/api/tenders/{tenderId}/rollback/{version}", tndrollback.New(log, storage))
+	router.Get("/api/tenders/{tenderId}/versions", tndversions.New(log, storage))
+	router.Get("/api/tenders/{tenderId}/versions/{from}/diff/{to}", tnddiff.New(log, storage))
+
+	router.Post("/api/bids/new", bidcreate.New(log, storage))
+	router.Get("/api/bids/my", userbidget.New(log, storage))
+	router.Get("/api/bids/{tenderId}/list", bidget.New(log, storage))
+	router.Get("/api/bids/status", bidstatus.New(log, storage))
+	router.Get("/api/bids/{bidId}/status", bidstatusget.New(log, storage))
+	router.Put("/api/bids/{bidId}/status", bidstatusput.New(log, storage))
+	router.Patch("/api/bids/{bidId}/edit", bidedit.New(log, storage))
+	router.Put("/api/bids/{bidId}/rollback/{version}", bidrollback.New(log, storage))
+	router.Get("/api/bids/{bidId}/versions", bidversions.New(log, storage))
+	router.Get("/api/bids/{bidId}/versions/{from}/diff/{to}", biddiff.New(log, storage))
+	router.Put("/api/bids/{bidId}/submit_decision", submit.New(log, storage))
+	router.Put("/api/bids/{bidId}/feedback", bidfeedback.New(log, storage))
+	router.Get("/api/bids/{tenderId}/reviews", reviewget.New(log, storage))
+
+	router.Get("/api/organizations", orgsget.New(log, storage))
+	router.Post("/api/organizations/new", orgcreate.New(log, storage))
+	router.Get("/api/organizations/{organizationId}", orgget.New(log, storage))
+	router.Patch("/api/organizations/{organizationId}/edit", orgedit.New(log, storage))
+	router.Delete("/api/organizations/{organizationId}", orgdelete.New(log, storage))
+	router.Get("/api/organizations/{organizationId}/responsibles", respget.New(log, storage))
+	router.Post("/api/organizations/{organizationId}/responsibles/{username}", respadd.New(log, storage))
+	router.Delete("/api/organizations/{organizationId}/responsibles/{username}", respremove.New(log, storage))
+
+	router.Get("/api/employees", empsget.New(log, storage))
+	router.Post("/api/employees/new", empcreate.New(log, storage))
+	router.Get("/api/employees/{employeeId}", empget.New(log, storage))
+	router.Patch("/api/employees/{employeeId}/edit", empedit.New(log, storage))
+	router.Delete("/api/employees/{employeeId}", empdelete.New(log, storage))
+
+	router.Get("/api/audit", auditget.New(log, storage))
+
+	return router
+}
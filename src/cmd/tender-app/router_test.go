@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"tender-app-backend/src/internal/config"
+	"tender-app-backend/src/internal/http-server/openapi"
+	"tender-app-backend/src/internal/lib/token"
+	"tender-app-backend/src/internal/storage/memory"
+	"testing"
+	"time"
+)
+
+const routerSeed = `{
+  "employees": [
+    {"id": "4c3f9a2e-8b1d-4f6a-9c2e-7d5b3a1f0e92", "username": "alice"},
+    {"id": "9b2d7e41-3c5a-4f8e-a1d6-2e7f4b9c0d53", "username": "carol"}
+  ],
+  "organizations": [
+    {"id": "550e8400-e29b-41d4-a716-446655440000", "name": "Customer"},
+    {"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "name": "Bidder"}
+  ],
+  "responsibles": [
+    {"organizationId": "550e8400-e29b-41d4-a716-446655440000", "username": "alice"},
+    {"organizationId": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "username": "carol"}
+  ]
+}`
+
+// TestRouterTokenOnly walks a tender to an approved bid through the whole
+// middleware chain, naming the actor only by the bearer token.
+func TestRouterTokenOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(path, []byte(routerSeed), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store := memory.New()
+	if err := store.LoadSeed(context.Background(), path); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		HttpServer: config.HttpServer{Timeout: 5 * time.Second, DevMode: true},
+		Auth:       config.Auth{TokenKey: "secret", TokenTTL: time.Hour},
+	}
+	router := newRouter(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, spec, store)
+	tokens := token.New(cfg.TokenKey, cfg.TokenTTL)
+
+	bearer := func(username string) string {
+		e, err := store.GetEmployeeByUsername(context.Background(), username)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := tokens.Issue(e.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+	alice, carol := bearer("alice"), bearer("carol")
+
+	do := func(raw, method, target, body string, code int) map[string]any {
+		t.Helper()
+
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+raw)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != code {
+			t.Fatalf("%s %s: got status %d, want %d: %s", method, target, rec.Code, code, rec.Body)
+		}
+
+		var v map[string]any
+		_ = json.Unmarshal(rec.Body.Bytes(), &v)
+		return v
+	}
+
+	tender := do(alice, http.MethodPost, "/api/tenders/new", `{
+		"name": "Delivery", "description": "Deliver equipment", "serviceType": "Delivery",
+		"organizationId": "550e8400-e29b-41d4-a716-446655440000"}`, http.StatusOK)
+	tenderId := tender["id"].(string)
+	do(alice, http.MethodPut, "/api/tenders/"+tenderId+"/status?status=Published", "", http.StatusOK)
+
+	bid := do(carol, http.MethodPost, "/api/bids/new", `{
+		"name": "By truck", "description": "Two days", "tenderId": "`+tenderId+`",
+		"organizationId": "7c9e6679-7425-40de-944b-e07fc1f90ae7"}`, http.StatusOK)
+	bidId := bid["id"].(string)
+	do(carol, http.MethodPut, "/api/bids/"+bidId+"/status?status=Published", "", http.StatusOK)
+
+	do(alice, http.MethodGet, "/api/bids/"+tenderId+"/list", "", http.StatusOK)
+	do(alice, http.MethodPut, "/api/bids/"+bidId+"/submit_decision?decision=Approved&username=carol", "", http.StatusUnauthorized)
+	do(alice, http.MethodPut, "/api/bids/"+bidId+"/submit_decision?decision=Approved", "", http.StatusOK)
+	do(carol, http.MethodGet, "/api/bids/"+bidId+"/status", "", http.StatusOK)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"tender-app-backend/src/internal/lib/logger/sl"
+	"time"
+)
+
+// serve runs srv on ln until ctx is done. It then stops accepting
+// connections and waits up to timeout for the requests in flight. Requests
+// still running past the deadline are cancelled with cancel and their
+// connections closed. serve returns the process exit code: 0 when every
+// request finished in time.
+func serve(ctx context.Context, log *slog.Logger, srv *http.Server, ln net.Listener, timeout time.Duration, cancel context.CancelFunc) int {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errs:
+		log.Error("server failed", sl.Err(err))
+		return 1
+	case <-ctx.Done():
+	}
+
+	log.Info("shutting down", slog.Duration("timeout", timeout))
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), timeout)
+	defer cancelShutdown()
+
+	err := srv.Shutdown(shutdownCtx)
+	if err == nil {
+		return 0
+	}
+
+	log.Error("requests did not finish in time", sl.Err(err))
+
+	cancel()
+	if err := srv.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		log.Error("failed to close connections", sl.Err(err))
+	}
+
+	return 1
+}
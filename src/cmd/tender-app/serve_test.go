@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name      string
+		work      time.Duration
+		code      int
+		cancelled bool
+	}{
+		{name: "drained", work: 10 * time.Millisecond, code: 0},
+		{name: "past deadline", work: time.Minute, code: 1, cancelled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, cancelQueries := context.WithCancel(context.Background())
+			defer cancelQueries()
+
+			started := make(chan struct{})
+			cancelled := make(chan bool, 1)
+			srv := &http.Server{
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					close(started)
+					select {
+					case <-time.After(tt.work):
+						cancelled <- false
+					case <-r.Context().Done():
+						cancelled <- true
+					}
+				}),
+				BaseContext: func(net.Listener) context.Context { return queries },
+			}
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, stop := context.WithCancel(context.Background())
+			code := make(chan int, 1)
+			go func() {
+				code <- serve(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), srv, ln, 100*time.Millisecond, cancelQueries)
+			}()
+
+			go http.Get("http://" + ln.Addr().String())
+			<-started
+			stop()
+
+			select {
+			case got := <-code:
+				if got != tt.code {
+					t.Fatalf("got exit code %d, want %d", got, tt.code)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("serve did not return")
+			}
+
+			if got := <-cancelled; got != tt.cancelled {
+				t.Fatalf("request cancelled: %v, want %v", got, tt.cancelled)
+			}
+		})
+	}
+}
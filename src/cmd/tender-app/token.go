@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"tender-app-backend/src/internal/config"
+	"tender-app-backend/src/internal/lib/logger/sl"
+	"tender-app-backend/src/internal/lib/token"
+)
+
+const tokenUsage = "usage: tender-app token <username>"
+
+// runToken handles the token subcommand: it prints a bearer token for the
+// employee and returns the process exit code.
+func runToken(cfg *config.Config, log *slog.Logger, args []string) int {
+	if len(args) != 1 {
+		fmt.Println(tokenUsage)
+		return 2
+	}
+
+	if cfg.TokenKey == "" {
+		log.Error("AUTH_TOKEN_KEY is not set")
+		return 1
+	}
+
+	storage, err := setupStorage(context.Background(), cfg)
+	if err != nil {
+		log.Error("failed to init storage", slog.String("driver", cfg.Driver), sl.Err(err))
+		return 1
+	}
+	defer storage.Close()
+
+	e, err := storage.GetEmployeeByUsername(context.Background(), args[0])
+	if err != nil {
+		log.Error("failed to get employee", slog.String("username", args[0]), sl.Err(err))
+		return 1
+	}
+
+	signed, err := token.New(cfg.TokenKey, cfg.TokenTTL).Issue(e.Id)
+	if err != nil {
+		log.Error("failed to issue token", sl.Err(err))
+		return 1
+	}
+
+	fmt.Println(signed)
+
+	return 0
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// AuditEvent records a change of a tender, bid, employee, organization or
+// responsible: who made it on behalf of which organization, and what the
+// entity looked like before and after. Before is empty for creations and
+// After for deletions.
+type AuditEvent struct {
+	Id             int64           `json:"id"`
+	Actor          string          `json:"actor"`
+	Action         string          `json:"action"`
+	EntityType     string          `json:"entityType"`
+	EntityId       uuid.UUID       `json:"entityId"`
+	OrganizationId uuid.UUID       `json:"organizationId"`
+	Before         json.RawMessage `json:"before,omitempty"`
+	After          json.RawMessage `json:"after,omitempty"`
+	RequestId      string          `json:"requestId,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+const (
+	EntityTender       = "tender"
+	EntityBid          = "bid"
+	EntityEmployee     = "employee"
+	EntityOrganization = "organization"
+	EntityResponsible  = "organization_responsible"
+)
+
+const (
+	ActionCreate   = "create"
+	ActionEdit     = "edit"
+	ActionDelete   = "delete"
+	ActionRollback = "rollback"
+	ActionPublish  = "publish"
+	ActionClose    = "close"
+	ActionCancel   = "cancel"
+	ActionApprove  = "approve"
+	ActionReject   = "reject"
+	ActionFeedback = "feedback"
+
+	ActionDecisionRecorded = "decision_recorded"
+)
+
+// SystemActor makes the changes the service does on its own, like closing
+// tenders past their bid deadline.
+const SystemActor = "system"
+
+var statusActions = map[string]string{
+	TenderPublished: ActionPublish,
+	TenderClosed:    ActionClose,
+	BidCanceled:     ActionCancel,
+	BidApproved:     ActionApprove,
+	BidRejected:     ActionReject,
+}
+
+// StatusAction names the action that moves a tender or bid to status.
+func StatusAction(status string) string {
+	return statusActions[status]
+}
+
+// DecisionAction names the action of submitting a decision on a bid,
+// given the bid status after it. Approvals short of the quorum only
+// record the decision.
+func DecisionAction(decision, status string) string {
+	switch {
+	case decision == DecisionRejected:
+		return ActionReject
+	case status == BidApproved:
+		return ActionApprove
+	}
+
+	return ActionDecisionRecorded
+}
+
+// AuditFilter selects events of one organization, zero fields match any.
+// From is inclusive and To exclusive.
+type AuditFilter struct {
+	OrganizationId uuid.UUID
+	EntityType     string
+	EntityId       uuid.UUID
+	From           time.Time
+	To             time.Time
+}
@@ -0,0 +1,99 @@
+// Package authz decides what an employee may do with tenders, bids,
+// organizations and other employees.
+//
+// Every action is guarded by a Policy declared in policy.go. A policy
+// allows the action when any of its rules holds for the acting employee
+// and the resource. Handlers evaluate the policy before calling storage;
+// storage still filters lists by the same rules, see tenderVisible and
+// bidVisible in storage/postgres.
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"tender-app-backend/src/internal"
+)
+
+var ErrDenied = errors.New("access denied")
+
+// DeniedError is returned when a policy does not allow the action.
+type DeniedError struct {
+	Policy   string
+	Username string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("policy %q denies %q", e.Policy, e.Username)
+}
+
+func (e *DeniedError) Unwrap() error {
+	return ErrDenied
+}
+
+// Facts answers the questions rules ask about the data.
+type Facts interface {
+	GetTender(ctx context.Context, tenderId uuid.UUID) (internal.Tender, error)
+	IsOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username string) (bool, error)
+}
+
+// Resource is what a policy is evaluated against.
+type Resource struct {
+	OrganizationId  uuid.UUID
+	CreatorUsername string
+	Status          string
+	// TenderId is the tender a bid is made on, nil for tenders.
+	TenderId uuid.UUID
+	// Username is set when the resource is an employee.
+	Username string
+}
+
+func Tender(t internal.Tender) Resource {
+	return Resource{OrganizationId: t.OrganizationId, CreatorUsername: t.CreatorUsername, Status: t.Status}
+}
+
+func Bid(b internal.Bid) Resource {
+	return Resource{OrganizationId: b.OrganizationId, CreatorUsername: b.CreatorUsername, Status: b.Status, TenderId: b.TenderId}
+}
+
+func Organization(o internal.Organization) Resource {
+	return Resource{OrganizationId: o.Id}
+}
+
+func Employee(e internal.Employee) Resource {
+	return Resource{Username: e.Username}
+}
+
+// Rule is one reason to allow an action.
+type Rule struct {
+	Name  string
+	holds func(ctx context.Context, f Facts, username string, res Resource) (bool, error)
+}
+
+// Policy allows an action when any of its rules holds.
+type Policy struct {
+	Name  string
+	Allow []Rule
+}
+
+// Check evaluates p for username acting on res. A denial is logged with
+// the policy name and returned as *DeniedError, other errors come from facts.
+func Check(ctx context.Context, log *slog.Logger, f Facts, p Policy, username string, res Resource) error {
+	const op = "authz.Check"
+
+	for _, rule := range p.Allow {
+		ok, err := rule.holds(ctx, f, username, res)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", op, rule.Name, err)
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	log.Info("access denied", slog.String("policy", p.Name), slog.String("username", username))
+
+	return &DeniedError{Policy: p.Name, Username: username}
+}
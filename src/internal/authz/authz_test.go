@@ -0,0 +1,132 @@
+package authz_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+var (
+	customer = uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	bidder   = uuid.MustParse("16fd2706-8baf-433b-82eb-8c7fada847da")
+	tenderId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+)
+
+// facts knows alice as a customer responsible and carol as a bidder one.
+type facts struct{}
+
+func (facts) GetTender(_ context.Context, id uuid.UUID) (internal.Tender, error) {
+	if id != tenderId {
+		return internal.Tender{}, storage.ErrTenderNotFound
+	}
+
+	return internal.Tender{Id: tenderId, OrganizationId: customer}, nil
+}
+
+func (facts) IsOrganizationResponsible(_ context.Context, orgId uuid.UUID, username string) (bool, error) {
+	switch username {
+	case "alice":
+		return orgId == customer, nil
+	case "carol":
+		return orgId == bidder, nil
+	case "broken":
+		return false, errors.New("db is down")
+	}
+
+	return false, nil
+}
+
+func TestPolicies(t *testing.T) {
+	draftTender := authz.Resource{OrganizationId: customer, CreatorUsername: "alice", Status: internal.TenderCreated}
+	publishedTender := authz.Resource{OrganizationId: customer, CreatorUsername: "alice", Status: internal.TenderPublished}
+	draftBid := authz.Resource{OrganizationId: bidder, CreatorUsername: "dave", Status: internal.BidCreated, TenderId: tenderId}
+	publishedBid := authz.Resource{OrganizationId: bidder, CreatorUsername: "dave", Status: internal.BidPublished, TenderId: tenderId}
+	organization := authz.Organization(internal.Organization{Id: customer})
+	employee := authz.Employee(internal.Employee{Username: "erin"})
+
+	tests := []struct {
+		policy   authz.Policy
+		username string
+		res      authz.Resource
+		allowed  bool
+	}{
+		{authz.ViewTender, "", draftTender, false},
+		{authz.ViewTender, "carol", draftTender, false},
+		{authz.ViewTender, "alice", draftTender, true},
+		{authz.ViewTender, "", publishedTender, true},
+		{authz.EditTender, "carol", publishedTender, false},
+		{authz.EditTender, "alice", publishedTender, true},
+		{authz.ChangeTenderStatus, "carol", draftTender, false},
+		{authz.ViewTenderVersions, "", publishedTender, false},
+		{authz.ViewTenderVersions, "alice", publishedTender, true},
+
+		{authz.ViewBid, "dave", draftBid, true},
+		{authz.ViewBid, "carol", draftBid, true},
+		{authz.ViewBid, "alice", draftBid, false},
+		{authz.ViewBid, "alice", publishedBid, true},
+		{authz.ViewBid, "erin", publishedBid, false},
+		{authz.ViewBid, "", publishedBid, false},
+		{authz.EditBid, "dave", publishedBid, true},
+		{authz.EditBid, "carol", publishedBid, true},
+		{authz.EditBid, "alice", publishedBid, false},
+		{authz.RollbackBid, "alice", publishedBid, false},
+		{authz.ViewBidVersions, "dave", publishedBid, true},
+		{authz.ViewBidVersions, "alice", publishedBid, false},
+		{authz.DecideBid, "alice", publishedBid, true},
+		{authz.DecideBid, "dave", publishedBid, false},
+		{authz.BidFeedback, "carol", publishedBid, false},
+		{authz.BidFeedback, "alice", publishedBid, true},
+		{authz.BidFeedback, "alice", draftBid, false},
+
+		{authz.EditOrganization, "alice", organization, true},
+		{authz.EditOrganization, "carol", organization, false},
+		{authz.ManageResponsibles, "", organization, false},
+		{authz.ViewAudit, "alice", organization, true},
+		{authz.ViewAudit, "carol", organization, false},
+		{authz.EditEmployee, "erin", employee, true},
+		{authz.DeleteEmployee, "alice", employee, false},
+		{authz.DeleteEmployee, "", authz.Employee(internal.Employee{}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.Name+"/"+tt.username, func(t *testing.T) {
+			err := authz.Check(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), facts{}, tt.policy, tt.username, tt.res)
+			if tt.allowed && err != nil {
+				t.Fatalf("denied: %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, authz.ErrDenied) {
+				t.Fatalf("got %v, want %v", err, authz.ErrDenied)
+			}
+		})
+	}
+}
+
+func TestCheckLogsDenials(t *testing.T) {
+	var logs bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&logs, nil))
+
+	err := authz.Check(context.Background(), log, facts{}, authz.EditTender, "carol", authz.Resource{OrganizationId: customer})
+
+	var denied *authz.DeniedError
+	if !errors.As(err, &denied) || denied.Policy != "edit tender" {
+		t.Fatalf("got %v, want denial by edit tender", err)
+	}
+	if !strings.Contains(logs.String(), `policy="edit tender"`) {
+		t.Fatalf("denial not logged with the policy, logs: %s", logs.String())
+	}
+}
+
+func TestCheckFactsError(t *testing.T) {
+	err := authz.Check(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), facts{}, authz.EditTender, "broken", authz.Resource{OrganizationId: customer})
+	if err == nil || errors.Is(err, authz.ErrDenied) {
+		t.Fatalf("got %v, want the facts error", err)
+	}
+}
@@ -0,0 +1,91 @@
+package authz
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+)
+
+var (
+	CreateTender       = Policy{Name: "create tender", Allow: []Rule{Responsible}}
+	ViewTender         = Policy{Name: "view tender", Allow: []Rule{Published, Responsible}}
+	EditTender         = Policy{Name: "edit tender", Allow: []Rule{Responsible}}
+	RollbackTender     = Policy{Name: "roll back tender", Allow: []Rule{Responsible}}
+	ViewTenderVersions = Policy{Name: "view tender versions", Allow: []Rule{Responsible}}
+	ChangeTenderStatus = Policy{Name: "change tender status", Allow: []Rule{Responsible}}
+	ViewBidReviews     = Policy{Name: "view bid reviews", Allow: []Rule{Responsible}}
+
+	CreateBid       = Policy{Name: "create bid", Allow: []Rule{Responsible}}
+	ViewBid         = Policy{Name: "view bid", Allow: []Rule{Author, Responsible, SubmittedToTenderResponsible}}
+	EditBid         = Policy{Name: "edit bid", Allow: []Rule{Author, Responsible}}
+	RollbackBid     = Policy{Name: "roll back bid", Allow: []Rule{Author, Responsible}}
+	ViewBidVersions = Policy{Name: "view bid versions", Allow: []Rule{Author, Responsible}}
+	ChangeBidStatus = Policy{Name: "change bid status", Allow: []Rule{Author, Responsible}}
+	DecideBid       = Policy{Name: "decide bid", Allow: []Rule{TenderResponsible}}
+	BidFeedback     = Policy{Name: "leave bid feedback", Allow: []Rule{SubmittedToTenderResponsible}}
+
+	EditOrganization   = Policy{Name: "edit organization", Allow: []Rule{Responsible}}
+	DeleteOrganization = Policy{Name: "delete organization", Allow: []Rule{Responsible}}
+	ManageResponsibles = Policy{Name: "manage organization responsibles", Allow: []Rule{Responsible}}
+	ViewAudit          = Policy{Name: "view audit", Allow: []Rule{Responsible}}
+
+	EditEmployee   = Policy{Name: "edit employee", Allow: []Rule{Self}}
+	DeleteEmployee = Policy{Name: "delete employee", Allow: []Rule{Self}}
+)
+
+var (
+	// Published holds for published resources, anyone may see them.
+	Published = Rule{Name: "published", holds: func(_ context.Context, _ Facts, _ string, res Resource) (bool, error) {
+		return res.Status == internal.TenderPublished, nil
+	}}
+
+	// Author holds for the employee who created the resource.
+	Author = Rule{Name: "author", holds: func(_ context.Context, _ Facts, username string, res Resource) (bool, error) {
+		return username != "" && username == res.CreatorUsername, nil
+	}}
+
+	// Self holds for the employee the resource is.
+	Self = Rule{Name: "self", holds: func(_ context.Context, _ Facts, username string, res Resource) (bool, error) {
+		return username != "" && username == res.Username, nil
+	}}
+
+	// Responsible holds for responsibles of the resource organization.
+	Responsible = Rule{Name: "responsible", holds: func(ctx context.Context, f Facts, username string, res Resource) (bool, error) {
+		return isResponsible(ctx, f, res.OrganizationId, username)
+	}}
+
+	// TenderResponsible holds for responsibles of the organization
+	// that announced the tender the bid is made on.
+	TenderResponsible = Rule{Name: "tender responsible", holds: tenderResponsible}
+
+	// SubmittedToTenderResponsible lets the tender organization see bids
+	// once they have left CREATED.
+	SubmittedToTenderResponsible = Rule{Name: "submitted to tender responsible", holds: func(ctx context.Context, f Facts, username string, res Resource) (bool, error) {
+		if res.Status == internal.BidCreated {
+			return false, nil
+		}
+
+		return tenderResponsible(ctx, f, username, res)
+	}}
+)
+
+func tenderResponsible(ctx context.Context, f Facts, username string, res Resource) (bool, error) {
+	if username == "" {
+		return false, nil
+	}
+
+	t, err := f.GetTender(ctx, res.TenderId)
+	if err != nil {
+		return false, err
+	}
+
+	return isResponsible(ctx, f, t.OrganizationId, username)
+}
+
+func isResponsible(ctx context.Context, f Facts, orgId uuid.UUID, username string) (bool, error) {
+	if username == "" {
+		return false, nil
+	}
+
+	return f.IsOrganizationResponsible(ctx, orgId, username)
+}
@@ -1,12 +1,75 @@
 package internal
 
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
 type Bid struct {
-	Id              int    `json:"id,omitempty"`
-	Name            string `json:"name,omitempty"`
-	Description     string `json:"description,omitempty"`
-	Status          string `json:"status,omitempty"`
-	TenderId        int    `json:"tenderId,omitempty" validate:"required"`
-	OrganizationId  int    `json:"organizationId,omitempty" validate:"required"`
-	CreatorUsername string `json:"creatorUsername,omitempty" validate:"required"`
-	Version         int    `json:"version,omitempty"`
+	Id              uuid.UUID `json:"id,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Description     string    `json:"description,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	TenderId        uuid.UUID `json:"tenderId,omitempty" validate:"required"`
+	OrganizationId  uuid.UUID `json:"organizationId,omitempty" validate:"required"`
+	CreatorUsername string    `json:"creatorUsername,omitempty" validate:"required"`
+	Version         int       `json:"version,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	LastEditedBy    string    `json:"lastEditedBy,omitempty"`
+}
+
+const (
+	BidCreated   = "CREATED"
+	BidPublished = "PUBLISHED"
+	BidCanceled  = "CANCELED"
+	BidApproved  = "APPROVED"
+	BidRejected  = "REJECTED"
+)
+
+const (
+	DecisionApproved = "Approved"
+	DecisionRejected = "Rejected"
+)
+
+// maxDecisionQuorum caps the number of approvals a bid needs.
+const maxDecisionQuorum = 3
+
+// bidTransitions lists the statuses a bid can move to from each status.
+// APPROVED and REJECTED are outcomes of the tender organization decisions.
+var bidTransitions = map[string][]string{
+	BidCreated:   {BidPublished},
+	BidPublished: {BidCanceled, BidApproved, BidRejected},
+}
+
+func CanChangeBidStatus(from, to string) bool {
+	for _, s := range bidTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func IsBidOutcome(status string) bool {
+	return status == BidApproved || status == BidRejected
+}
+
+func IsBidDecision(decision string) bool {
+	return decision == DecisionApproved || decision == DecisionRejected
+}
+
+// DecisionQuorum returns how many approvals a bid needs to be approved
+// when the tender organization has the given number of responsibles.
+func DecisionQuorum(responsibles int) int {
+	return min(maxDecisionQuorum, responsibles)
+}
+
+// DiffBids lists the editable fields that differ between two versions
+// of a bid.
+func DiffBids(from, to Bid) []Change {
+	return changes{}.
+		add("name", from.Name, to.Name).
+		add("description", from.Description, to.Description)
 }
@@ -1,21 +1,38 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"io/fs"
 	"log"
 	"time"
 )
 
 type Config struct {
 	HttpServer
+	Storage
 	Postgres
+	Auth
+	Scheduler
 }
 
 type HttpServer struct {
 	ServerAddress string        `envconfig:"SERVER_ADDRESS" default:"0.0.0.0:8080"`
 	Timeout       time.Duration `envconfig:"SERVER_TIMEOUT" default:"4s"`
 	IdleTimeout   time.Duration `envconfig:"SERVER_IDLE_TIMEOUT" default:"60s"`
+	// ShutdownTimeout is how long requests in flight may take to finish
+	// after SIGTERM, the rest is cancelled.
+	ShutdownTimeout time.Duration `envconfig:"SERVER_SHUTDOWN_TIMEOUT" default:"10s"`
+	// DevMode also checks responses against the API spec.
+	DevMode bool `envconfig:"DEV_MODE" default:"false"`
+}
+
+// Storage selects the backend: "postgres" (default) or "memory".
+type Storage struct {
+	Driver         string `envconfig:"STORAGE_DRIVER" default:"postgres"`
+	MemorySeedFile string `envconfig:"MEMORY_SEED_FILE"`
 }
 
 type Postgres struct {
@@ -28,8 +45,20 @@ type Postgres struct {
 	Database string `envconfig:"POSTGRES_DATABASE"`
 }
 
+// Auth signs the bearer tokens employees authenticate with.
+type Auth struct {
+	TokenKey string        `envconfig:"AUTH_TOKEN_KEY"`
+	TokenTTL time.Duration `envconfig:"AUTH_TOKEN_TTL" default:"24h"`
+}
+
+// Scheduler runs background jobs, such as closing tenders past their
+// bid deadline.
+type Scheduler struct {
+	DeadlineInterval time.Duration `envconfig:"SCHEDULER_DEADLINE_INTERVAL" default:"1m"`
+}
+
 func MustLoad() *Config {
-	if err := godotenv.Load(); err != nil {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		log.Fatal("Error loading env variables", err)
 	}
 
@@ -39,5 +68,18 @@ func MustLoad() *Config {
 		log.Fatal("Error processing env variables:", err)
 	}
 
+	if err = cfg.Validate(); err != nil {
+		log.Fatal("Invalid env variables: ", err)
+	}
+
 	return &cfg
 }
+
+// Validate reports settings that parse but can not be used.
+func (c *Config) Validate() error {
+	if c.DeadlineInterval <= 0 {
+		return fmt.Errorf("SCHEDULER_DEADLINE_INTERVAL must be positive, got %s", c.DeadlineInterval)
+	}
+
+	return nil
+}
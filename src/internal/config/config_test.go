@@ -0,0 +1,30 @@
+package config_test
+
+import (
+	"tender-app-backend/src/internal/config"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		wantErr  bool
+	}{
+		{name: "positive", interval: time.Minute},
+		{name: "zero", interval: 0, wantErr: true},
+		{name: "negative", interval: -time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{Scheduler: config.Scheduler{DeadlineInterval: tt.interval}}
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package internal
+
+import "github.com/google/uuid"
+
+type Employee struct {
+	Id        uuid.UUID `json:"id,omitempty"`
+	Username  string    `json:"username,omitempty" validate:"required,max=50"`
+	FirstName string    `json:"firstName,omitempty" validate:"max=50"`
+	LastName  string    `json:"lastName,omitempty" validate:"max=50"`
+}
@@ -1,16 +1,17 @@
 package bidcreate
 
 import (
-	"errors"
+	"context"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
 	"log/slog"
 	"net/http"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
-	"tender-app-backend/src/internal/storage"
 )
 
 type Request struct {
@@ -18,14 +19,15 @@ type Request struct {
 }
 
 type BidCreator interface {
-	CreateBid(b internal.Bid) (internal.Bid, error)
+	authz.Facts
+	CreateBid(ctx context.Context, b internal.Bid) (internal.Bid, error)
 }
 
 func New(log *slog.Logger, bidCreator BidCreator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.create.bidcreate.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -34,51 +36,45 @@ func New(log *slog.Logger, bidCreator BidCreator) http.HandlerFunc {
 
 		err := render.DecodeJSON(r.Body, &req.Bid)
 		if err != nil {
-			log.Error("failed to decode request body", sl.Err(err))
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		// TODO: add correct validation
-		if err := validator.New().Struct(req.Bid); err != nil {
-			log.Error("invalid request", sl.Err(err))
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		req.Bid.CreatorUsername, err = auth.Actor(r, req.Bid.CreatorUsername)
+		if err != nil {
+			response.Fail(w, r, log, err)
 
 			return
 		}
 
-		bid, err := bidCreator.CreateBid(req.Bid)
-		if err != nil {
-			if errors.Is(err, storage.ErrOrgRespNotFound) {
-				log.Info(
-					"organisation responsible user not found",
-					slog.String("creator_username", req.Bid.CreatorUsername),
-				)
+		// The request already matches the API spec, see middleware/validate.
+		if err := validator.New().Struct(req.Bid); err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
-				render.Status(r, http.StatusForbidden)
-				render.JSON(w, r, response.Error("user is unable to create bids"))
+			return
+		}
 
-				return
-			}
+		err = authz.Check(r.Context(), log, bidCreator, authz.CreateBid, req.Bid.CreatorUsername, authz.Bid(req.Bid))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "create bid"))
 
-			log.Error("failed to create bid", sl.Err(err))
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to create bid"))
+		bid, err := bidCreator.CreateBid(r.Context(), req.Bid)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "create bid"))
 
 			return
 		}
 
 		log.Info("bid created", slog.Any("bid", bid))
 
+		etag.Set(w, bid.Version)
+
 		render.JSON(w, r, bid)
 	}
 }
-
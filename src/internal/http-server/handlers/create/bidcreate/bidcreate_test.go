@@ -0,0 +1,84 @@
+package bidcreate_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/create/bidcreate"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+const validBody = `{
+	"name": "Delivery by truck",
+	"description": "Two days door to door",
+	"tenderId": "550e8400-e29b-41d4-a716-446655440000",
+	"organizationId": "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+	"creatorUsername": "user2"
+}`
+
+func TestCreateBid(t *testing.T) {
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		body      string
+		createErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "created", body: validBody, code: http.StatusOK},
+		{name: "malformed body", body: `{"name":`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "no tender", body: `{"name": "x", "organizationId": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "creatorUsername": "user2"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "unknown user", body: validBody, createErr: storage.ErrUserNotFound, code: http.StatusUnauthorized, errMsg: "user does not exist"},
+		{name: "not responsible", body: validBody, createErr: storage.ErrOrgRespNotFound, code: http.StatusForbidden, errMsg: "user is not allowed to create bid"},
+		{name: "tender not found", body: validBody, createErr: storage.ErrTenderNotFound, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "bid deadline passed", body: validBody, createErr: storage.ErrBidDeadlinePassed, code: http.StatusForbidden, errMsg: "tender bid deadline has passed"},
+		{name: "storage failure", body: validBody, createErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to create bid"},
+		{name: "anonymous", body: validBody, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other creator", body: strings.Replace(validBody, "user2", "user1", 1), code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+		{name: "not allowed", as: "user3", body: `{"name": "x", "description": "x", "tenderId": "550e8400-e29b-41d4-a716-446655440000", "organizationId": "7c9e6679-7425-40de-944b-e07fc1f90ae7"}`, code: http.StatusForbidden, errMsg: "user is not allowed to create bid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got internal.Bid
+			fake := &handlertest.Storage{
+				CreateBidFunc: func(_ context.Context, b internal.Bid) (internal.Bid, error) {
+					got = b
+					if tt.createErr != nil {
+						return internal.Bid{}, tt.createErr
+					}
+					return handlertest.Bid(), nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user2")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodPost, "/api/bids/new", bidcreate.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPost, "/api/bids/new", tt.body).WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			if got.TenderId != handlertest.TenderId || got.CreatorUsername != "user2" {
+				t.Fatalf("storage got bid %+v", got)
+			}
+
+			var bid internal.Bid
+			resp.Decode(&bid)
+			if bid != handlertest.Bid() {
+				t.Fatalf("got bid %+v, want %+v", bid, handlertest.Bid())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package empcreate
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type Request struct {
+	Employee internal.Employee
+}
+
+type EmployeeCreator interface {
+	CreateEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error)
+}
+
+// New registers an employee. Any employee may onboard a colleague.
+func New(log *slog.Logger, employeeCreator EmployeeCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.create.empcreate.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req.Employee)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		if err := validator.New().Struct(req.Employee); err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		employee, err := employeeCreator.CreateEmployee(r.Context(), req.Employee, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromEmployeeStorage(err, "create employee"))
+
+			return
+		}
+
+		log.Info("employee created", slog.Any("employee", employee))
+
+		render.JSON(w, r, employee)
+	}
+}
@@ -0,0 +1,68 @@
+package empcreate_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/create/empcreate"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestCreateEmployee(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous bool
+		body      string
+		createErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "created", body: `{"username": "user4", "firstName": "Anna"}`, code: http.StatusOK},
+		{name: "malformed body", body: `{"username":`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "no username", body: `{"firstName": "Anna"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "exists", body: `{"username": "user4"}`, createErr: storage.ErrAlreadyExists, code: http.StatusConflict, errMsg: "already exists"},
+		{name: "storage failure", body: `{"username": "user4"}`, createErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to create employee"},
+		{name: "anonymous", anonymous: true, body: `{"username": "user4"}`, code: http.StatusUnauthorized, errMsg: "authentication required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				CreateEmployeeFunc: func(_ context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+					if tt.createErr != nil {
+						return internal.Employee{}, tt.createErr
+					}
+					if e.Username != "user4" || actor != "user1" {
+						t.Fatalf("storage got employee %+v, actor %s", e, actor)
+					}
+
+					e.Id = uuid.New()
+					return e, nil
+				},
+			}
+
+			as := "user1"
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodPost, "/api/employees/new", empcreate.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodPost, "/api/employees/new", tt.body).WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var e internal.Employee
+			resp.Decode(&e)
+			if e.Id == uuid.Nil || e.Username != "user4" || e.FirstName != "Anna" {
+				t.Fatalf("got employee %+v", e)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package orgcreate
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type Request struct {
+	Organization internal.Organization
+}
+
+type OrganizationCreator interface {
+	CreateOrganization(ctx context.Context, o internal.Organization, actor string, responsibles ...string) (internal.Organization, error)
+}
+
+// New creates an organization. The employee creating it becomes its
+// first responsible, so somebody can manage it from the start.
+func New(log *slog.Logger, organizationCreator OrganizationCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.create.orgcreate.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req.Organization)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		if err := validator.New().Struct(req.Organization); err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		organization, err := organizationCreator.CreateOrganization(r.Context(), req.Organization, username, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "create organization"))
+
+			return
+		}
+
+		log.Info("organization created", slog.Any("organization", organization))
+
+		render.JSON(w, r, organization)
+	}
+}
@@ -0,0 +1,69 @@
+package orgcreate_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/create/orgcreate"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestCreateOrganization(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous bool
+		body      string
+		createErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "created", body: `{"name": "Builder", "type": "LLC"}`, code: http.StatusOK},
+		{name: "malformed body", body: `{"name":`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "no name", body: `{"type": "LLC"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "unknown type", body: `{"name": "Builder", "type": "Ltd"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "exists", body: `{"name": "Builder"}`, createErr: storage.ErrAlreadyExists, code: http.StatusConflict, errMsg: "already exists"},
+		{name: "storage failure", body: `{"name": "Builder"}`, createErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to create organization"},
+		{name: "anonymous", anonymous: true, body: `{"name": "Builder"}`, code: http.StatusUnauthorized, errMsg: "authentication required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				CreateOrganizationFunc: func(_ context.Context, o internal.Organization, actor string, responsibles ...string) (internal.Organization, error) {
+					if tt.createErr != nil {
+						return internal.Organization{}, tt.createErr
+					}
+					if o.Name != "Builder" || actor != "user3" || !slices.Equal(responsibles, []string{"user3"}) {
+						t.Fatalf("storage got organization %+v, actor %s, responsibles %v", o, actor, responsibles)
+					}
+
+					o.Id = handlertest.OrganizationId
+					return o, nil
+				},
+			}
+
+			as := "user3"
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodPost, "/api/organizations/new", orgcreate.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodPost, "/api/organizations/new", tt.body).WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var o internal.Organization
+			resp.Decode(&o)
+			if o.Id != handlertest.OrganizationId || o.Type != "LLC" {
+				t.Fatalf("got organization %+v", o)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tndcreate
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -8,9 +9,11 @@ import (
 	"log/slog"
 	"net/http"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
-	"tender-app-backend/src/internal/storage"
+	"time"
 )
 
 type Request struct {
@@ -18,14 +21,15 @@ type Request struct {
 }
 
 type TenderCreator interface {
-	CreateTender(t internal.Tender) (internal.Tender, error)
+	authz.Facts
+	CreateTender(ctx context.Context, t internal.Tender) (internal.Tender, error)
 }
 
 func New(log *slog.Logger, tenderCreator TenderCreator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.create.tndcreate.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -34,50 +38,51 @@ func New(log *slog.Logger, tenderCreator TenderCreator) http.HandlerFunc {
 
 		err := render.DecodeJSON(r.Body, &req.Tender)
 		if err != nil {
-			log.Error("failed to decode request body", sl.Err(err))
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		// TODO: add correct validation
-		if err := validator.New().Struct(req.Tender); err != nil {
-			log.Error("invalid request", sl.Err(err))
+		req.Tender.CreatorUsername, err = auth.Actor(r, req.Tender.CreatorUsername)
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		// The request already matches the API spec, see middleware/validate.
+		if err := validator.New().Struct(req.Tender); err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		tender, err := tenderCreator.CreateTender(req.Tender)
-		if err != nil {
-			if errors.Is(err, storage.ErrOrgRespNotFound) {
-				log.Info(
-					"organisation responsible user not found",
-					slog.String("creator_username", req.Tender.CreatorUsername),
-				)
+		if req.Tender.BidDeadline != nil && !req.Tender.BidDeadline.After(time.Now()) {
+			response.Fail(w, r, log, response.BadRequest(errors.New("bidDeadline must be in the future")))
 
-				render.Status(r, http.StatusForbidden)
-				render.JSON(w, r, response.Error("user is unable to create tenders"))
+			return
+		}
 
-				return
-			}
+		err = authz.Check(r.Context(), log, tenderCreator, authz.CreateTender, req.Tender.CreatorUsername, authz.Tender(req.Tender))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "create tender"))
 
-			log.Error("failed to create tender", sl.Err(err))
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to create tender"))
+		tender, err := tenderCreator.CreateTender(r.Context(), req.Tender)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "create tender"))
 
 			return
 		}
 
 		log.Info("tender created", slog.Any("tender", tender))
 
+		etag.Set(w, tender.Version)
+
 		render.JSON(w, r, tender)
 	}
 }
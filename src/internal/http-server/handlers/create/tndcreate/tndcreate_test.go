@@ -0,0 +1,92 @@
+package tndcreate_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/create/tndcreate"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+const validBody = `{
+	"name": "Delivery Kazan - Moscow",
+	"description": "Deliver robotics olympiad equipment",
+	"serviceType": "Delivery",
+	"organizationId": "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+	"creatorUsername": "user1"
+}`
+
+// tokenBody leaves the creator to the bearer token.
+const tokenBody = `{
+	"name": "Delivery Kazan - Moscow",
+	"description": "Deliver robotics olympiad equipment",
+	"serviceType": "Delivery",
+	"organizationId": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+}`
+
+func TestCreateTender(t *testing.T) {
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		body      string
+		createErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "created", body: validBody, code: http.StatusOK},
+		{name: "with bid deadline", body: strings.Replace(validBody, `"name"`, `"bidDeadline": "2100-01-01T00:00:00Z", "name"`, 1), code: http.StatusOK},
+		{name: "bid deadline passed", body: strings.Replace(validBody, `"name"`, `"bidDeadline": "2024-01-01T00:00:00Z", "name"`, 1), code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "malformed body", body: `{"name":`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "creator from token", body: tokenBody, code: http.StatusOK},
+		{name: "unknown user", body: validBody, createErr: storage.ErrUserNotFound, code: http.StatusUnauthorized, errMsg: "user does not exist"},
+		{name: "not responsible", body: validBody, createErr: storage.ErrOrgRespNotFound, code: http.StatusForbidden, errMsg: "user is not allowed to create tender"},
+		{name: "storage failure", body: validBody, createErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to create tender"},
+		{name: "anonymous", body: validBody, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other creator", body: strings.Replace(validBody, "user1", "user2", 1), code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+		{name: "not allowed", as: "user3", body: tokenBody, code: http.StatusForbidden, errMsg: "user is not allowed to create tender"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got internal.Tender
+			fake := &handlertest.Storage{
+				CreateTenderFunc: func(_ context.Context, tender internal.Tender) (internal.Tender, error) {
+					got = tender
+					if tt.createErr != nil {
+						return internal.Tender{}, tt.createErr
+					}
+					return handlertest.Tender(), nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodPost, "/api/tenders/new", tndcreate.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPost, "/api/tenders/new", tt.body).WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			if got.CreatorUsername != "user1" || got.OrganizationId != handlertest.OrganizationId {
+				t.Fatalf("storage got tender %+v", got)
+			}
+
+			var tender internal.Tender
+			resp.Decode(&tender)
+			if tender != handlertest.Tender() {
+				t.Fatalf("got tender %+v, want %+v", tender, handlertest.Tender())
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package empdelete
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type EmployeeDeleter interface {
+	authz.Facts
+	GetEmployee(ctx context.Context, id uuid.UUID) (internal.Employee, error)
+	DeleteEmployee(ctx context.Context, id uuid.UUID, actor string) error
+}
+
+// New deletes an employee. The last responsible of an organization is kept,
+// the request is answered with 409.
+func New(log *slog.Logger, employeeDeleter EmployeeDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.delete.empdelete.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		employeeId, err := uuid.Parse(chi.URLParam(r, "employeeId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := employeeDeleter.GetEmployee(r.Context(), employeeId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromEmployeeStorage(err, "delete employee"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, employeeDeleter, authz.DeleteEmployee, username, authz.Employee(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "delete employee"))
+
+			return
+		}
+
+		err = employeeDeleter.DeleteEmployee(r.Context(), employeeId, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromEmployeeStorage(err, "delete employee"))
+
+			return
+		}
+
+		log.Info("employee deleted", slog.String("employee_id", employeeId.String()))
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
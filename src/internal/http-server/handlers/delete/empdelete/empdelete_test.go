@@ -0,0 +1,61 @@
+package empdelete_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal/http-server/handlers/delete/empdelete"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestDeleteEmployee(t *testing.T) {
+	target := "/api/employees/" + handlertest.EmployeeId.String()
+
+	tests := []struct {
+		name      string
+		as        string
+		target    string
+		deleteErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "deleted", target: target, code: http.StatusNoContent},
+		{name: "invalid id", target: "/api/employees/42", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "not found", target: "/api/employees/" + handlertest.TenderId.String(), code: http.StatusNotFound, errMsg: "employee not found"},
+		{name: "last responsible", target: target, deleteErr: storage.ErrLastResponsible, code: http.StatusConflict, errMsg: "organization has no other responsible"},
+		{name: "storage failure", target: target, deleteErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to delete employee"},
+		{name: "somebody else", as: "user2", target: target, code: http.StatusForbidden, errMsg: "user is not allowed to delete employee"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			fake := &handlertest.Storage{
+				DeleteEmployeeFunc: func(_ context.Context, id uuid.UUID, actor string) error {
+					if id != handlertest.EmployeeId || actor != "user1" {
+						t.Fatalf("storage got employee %s, actor %s", id, actor)
+					}
+					deleted = tt.deleteErr == nil
+					return tt.deleteErr
+				},
+			}
+
+			srv := handlertest.New(t).As(cmp.Or(tt.as, "user1")).
+				Handle(http.MethodDelete, "/api/employees/{employeeId}", empdelete.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodDelete, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			if !deleted {
+				t.Fatal("employee not deleted")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package orgdelete
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type OrganizationDeleter interface {
+	authz.Facts
+	GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error)
+	DeleteOrganization(ctx context.Context, orgId uuid.UUID, actor string) error
+}
+
+// New deletes an organization. Organizations with tenders or bids are kept,
+// the request is answered with 409.
+func New(log *slog.Logger, organizationDeleter OrganizationDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.delete.orgdelete.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		orgId, err := uuid.Parse(chi.URLParam(r, "organizationId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := organizationDeleter.GetOrganization(r.Context(), orgId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "delete organization"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, organizationDeleter, authz.DeleteOrganization, username, authz.Organization(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "delete organization"))
+
+			return
+		}
+
+		err = organizationDeleter.DeleteOrganization(r.Context(), orgId, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "delete organization"))
+
+			return
+		}
+
+		log.Info("organization deleted", slog.String("organization_id", orgId.String()))
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
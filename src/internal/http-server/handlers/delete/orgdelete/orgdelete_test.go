@@ -0,0 +1,67 @@
+package orgdelete_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal/http-server/handlers/delete/orgdelete"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestDeleteOrganization(t *testing.T) {
+	target := "/api/organizations/" + handlertest.OrganizationId.String()
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		deleteErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "deleted", target: target, code: http.StatusNoContent},
+		{name: "invalid id", target: "/api/organizations/42", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "not found", target: "/api/organizations/" + handlertest.TenderId.String(), code: http.StatusNotFound, errMsg: "organization not found"},
+		{name: "in use", target: target, deleteErr: storage.ErrOrganizationInUse, code: http.StatusConflict, errMsg: "organization has tenders or bids"},
+		{name: "storage failure", target: target, deleteErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to delete organization"},
+		{name: "anonymous", anonymous: true, target: target, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "not allowed", as: "user3", target: target, code: http.StatusForbidden, errMsg: "user is not allowed to delete organization"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			fake := &handlertest.Storage{
+				DeleteOrganizationFunc: func(_ context.Context, orgId uuid.UUID, actor string) error {
+					if orgId != handlertest.OrganizationId || actor != "user1" {
+						t.Fatalf("storage got organization %s, actor %s", orgId, actor)
+					}
+					deleted = tt.deleteErr == nil
+					return tt.deleteErr
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodDelete, "/api/organizations/{organizationId}", orgdelete.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodDelete, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			if !deleted {
+				t.Fatal("organization not deleted")
+			}
+		})
+	}
+}
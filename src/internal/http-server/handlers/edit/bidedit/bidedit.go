@@ -1,17 +1,18 @@
 package bidedit
 
 import (
-	"errors"
+	"context"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"log/slog"
 	"net/http"
-	"strconv"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
-	"tender-app-backend/src/internal/storage"
 )
 
 type Request struct {
@@ -19,14 +20,16 @@ type Request struct {
 }
 
 type BidEditor interface {
-	EditBid(b internal.Bid, editId int) (internal.Bid, error)
+	authz.Facts
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	EditBid(ctx context.Context, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error)
 }
 
 func New(log *slog.Logger, bidEditor BidEditor) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.edit.bidedit.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -35,60 +38,59 @@ func New(log *slog.Logger, bidEditor BidEditor) http.HandlerFunc {
 
 		err := render.DecodeJSON(r.Body, &req.Bid)
 		if err != nil {
-			log.Error("failed to decode request body", sl.Err(err))
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		bidIdStr := chi.URLParam(r, "bidId")
-		if bidIdStr == "" {
-			log.Info("bid id is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		bidId, err := uuid.Parse(chi.URLParam(r, "bidId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		bidId, err := strconv.Atoi(bidIdStr)
+		expected, err := etag.Expected(r, req.Bid.Version)
 		if err != nil {
-			log.Info("failed to parse bid id")
+			response.Fail(w, r, log, response.BadRequest(err))
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+			return
+		}
+
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
+		if err != nil {
+			response.Fail(w, r, log, err)
 
 			return
 		}
 
-		bid, err := bidEditor.EditBid(req.Bid, bidId	)
+		current, err := bidEditor.GetBid(r.Context(), bidId)
 		if err != nil {
-			if errors.Is(err, storage.ErrBidNotFound) {
-				log.Info(
-					"bid not found",
-					slog.String("bid_id", bidIdStr),
-				)
+			response.Fail(w, r, log, response.FromStorage(err, "edit bid"))
 
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, response.Error("bid not found"))
+			return
+		}
 
-				return
-			}
+		err = authz.Check(r.Context(), log, bidEditor, authz.EditBid, username, authz.Bid(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "edit bid"))
 
-			log.Error("failed to edit bid", sl.Err(err))
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to edit bid"))
+		bid, err := bidEditor.EditBid(r.Context(), req.Bid, bidId, expected, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "edit bid"))
 
 			return
 		}
 
 		log.Info("bid edited", slog.Any("bid", bid))
 
+		etag.Set(w, bid.Version)
+
 		render.JSON(w, r, bid)
 	}
 }
@@ -0,0 +1,91 @@
+package bidedit_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/edit/bidedit"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestEditBid(t *testing.T) {
+	target := "/api/bids/" + handlertest.BidId.String() + "/edit"
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		ifMatch   string
+		expected  int
+		body      string
+		editErr   error
+		code      int
+		errMsg    string
+	}{
+		{name: "edited", target: target, body: `{"description": "One day"}`, code: http.StatusOK},
+		{name: "malformed body", target: target, body: `[`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid id", target: "/api/bids/42/edit", body: `{"description": "One day"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "bid not found", target: target, body: `{"description": "One day"}`, editErr: storage.ErrBidNotFound, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "storage failure", target: target, body: `{"description": "One day"}`, editErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to edit bid"},
+		{name: "expected version in body", target: target, body: `{"description": "One day", "version": 1}`, expected: 1, code: http.StatusOK},
+		{name: "expected version in If-Match", target: target, body: `{"description": "One day"}`, ifMatch: `"1"`, expected: 1, code: http.StatusOK},
+		{name: "If-Match and body disagree", target: target, body: `{"description": "One day", "version": 1}`, ifMatch: `"2"`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "malformed If-Match", target: target, body: `{"description": "One day"}`, ifMatch: "1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "version conflict", target: target, body: `{"description": "One day"}`, ifMatch: `"1"`, expected: 1, editErr: storage.ErrVersionConflict, code: http.StatusConflict, errMsg: "version is not the current one"},
+		{name: "anonymous", target: target, body: `{"description": "One day"}`, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "not allowed", as: "user3", target: target, body: `{"description": "One day"}`, code: http.StatusForbidden, errMsg: "user is not allowed to edit bid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				EditBidFunc: func(_ context.Context, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error) {
+					if username != cmp.Or(tt.as, "user2") {
+						t.Fatalf("storage got author %q", username)
+					}
+					if expected != tt.expected {
+						t.Fatalf("storage got expected version %d, want %d", expected, tt.expected)
+					}
+					if tt.editErr != nil {
+						return internal.Bid{}, tt.editErr
+					}
+					if editId != handlertest.BidId || b.Description != "One day" {
+						t.Fatalf("storage got bid %+v, id %s", b, editId)
+					}
+
+					edited := handlertest.Bid()
+					edited.Description = b.Description
+					edited.Version = 2
+					return edited, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user2")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).WithHeader("If-Match", tt.ifMatch).Handle(http.MethodPatch, "/api/bids/{bidId}/edit", bidedit.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPatch, tt.target, tt.body).WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			resp.WantHeader("ETag", `"2"`)
+
+			var bid internal.Bid
+			resp.Decode(&bid)
+			if bid.Description != "One day" || bid.Version != 2 {
+				t.Fatalf("got bid %+v", bid)
+			}
+		})
+	}
+}
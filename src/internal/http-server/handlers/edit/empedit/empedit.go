@@ -0,0 +1,108 @@
+package empedit
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+// Request lists the fields that can be edited, omitted ones keep their values.
+// Username may be sent back as is but can not change.
+type Request struct {
+	Username  string `json:"username"`
+	FirstName string `json:"firstName" validate:"max=50"`
+	LastName  string `json:"lastName" validate:"max=50"`
+}
+
+type EmployeeEditor interface {
+	authz.Facts
+	GetEmployee(ctx context.Context, id uuid.UUID) (internal.Employee, error)
+	EditEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error)
+}
+
+func New(log *slog.Logger, employeeEditor EmployeeEditor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.edit.empedit.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		employeeId, err := uuid.Parse(chi.URLParam(r, "employeeId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := employeeEditor.GetEmployee(r.Context(), employeeId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromEmployeeStorage(err, "edit employee"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, employeeEditor, authz.EditEmployee, username, authz.Employee(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "edit employee"))
+
+			return
+		}
+
+		if req.Username != "" && req.Username != current.Username {
+			response.Fail(w, r, log, response.BadRequest(errors.New("username can not be changed")))
+
+			return
+		}
+
+		employee, err := employeeEditor.EditEmployee(r.Context(), internal.Employee{
+			Id:        employeeId,
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+		}, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromEmployeeStorage(err, "edit employee"))
+
+			return
+		}
+
+		log.Info("employee edited", slog.Any("employee", employee))
+
+		render.JSON(w, r, employee)
+	}
+}
@@ -0,0 +1,69 @@
+package empedit_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/edit/empedit"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestEditEmployee(t *testing.T) {
+	target := "/api/employees/" + handlertest.EmployeeId.String() + "/edit"
+
+	tests := []struct {
+		name    string
+		as      string
+		target  string
+		body    string
+		editErr error
+		code    int
+		errMsg  string
+	}{
+		{name: "edited", target: target, body: `{"firstName": "Anna"}`, code: http.StatusOK},
+		{name: "same username", target: target, body: `{"username": "user1", "firstName": "Anna"}`, code: http.StatusOK},
+		{name: "username changed", target: target, body: `{"username": "user9", "firstName": "Anna"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "malformed body", target: target, body: `{"firstName":`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid id", target: "/api/employees/42/edit", body: `{"firstName": "Anna"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "not found", target: "/api/employees/" + handlertest.TenderId.String() + "/edit", body: `{"firstName": "Anna"}`, code: http.StatusNotFound, errMsg: "employee not found"},
+		{name: "storage failure", target: target, body: `{"firstName": "Anna"}`, editErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to edit employee"},
+		{name: "somebody else", as: "user2", target: target, body: `{"firstName": "Anna"}`, code: http.StatusForbidden, errMsg: "user is not allowed to edit employee"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				EditEmployeeFunc: func(_ context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+					if tt.editErr != nil {
+						return internal.Employee{}, tt.editErr
+					}
+					if e.Id != handlertest.EmployeeId || e.FirstName != "Anna" || e.LastName != "" || actor != "user1" {
+						t.Fatalf("storage got employee %+v, actor %s", e, actor)
+					}
+
+					edited := handlertest.Employee()
+					edited.FirstName = e.FirstName
+					return edited, nil
+				},
+			}
+
+			srv := handlertest.New(t).As(cmp.Or(tt.as, "user1")).
+				Handle(http.MethodPatch, "/api/employees/{employeeId}/edit", empedit.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPatch, tt.target, tt.body).WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var e internal.Employee
+			resp.Decode(&e)
+			if e.FirstName != "Anna" || e.LastName != handlertest.Employee().LastName {
+				t.Fatalf("got employee %+v", e)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package orgedit
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+// Request lists the fields that can be edited, omitted ones keep their values.
+type Request struct {
+	Name        string `json:"name" validate:"max=100"`
+	Description string `json:"description"`
+	Type        string `json:"type" validate:"omitempty,oneof=IE LLC JSC"`
+}
+
+type OrganizationEditor interface {
+	authz.Facts
+	GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error)
+	EditOrganization(ctx context.Context, o internal.Organization, actor string) (internal.Organization, error)
+}
+
+func New(log *slog.Logger, organizationEditor OrganizationEditor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.edit.orgedit.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		orgId, err := uuid.Parse(chi.URLParam(r, "organizationId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := organizationEditor.GetOrganization(r.Context(), orgId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "edit organization"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, organizationEditor, authz.EditOrganization, username, authz.Organization(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "edit organization"))
+
+			return
+		}
+
+		organization, err := organizationEditor.EditOrganization(r.Context(), internal.Organization{
+			Id:          orgId,
+			Name:        req.Name,
+			Description: req.Description,
+			Type:        req.Type,
+		}, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "edit organization"))
+
+			return
+		}
+
+		log.Info("organization edited", slog.Any("organization", organization))
+
+		render.JSON(w, r, organization)
+	}
+}
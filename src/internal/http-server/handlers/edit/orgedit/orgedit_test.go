@@ -0,0 +1,69 @@
+package orgedit_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/edit/orgedit"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestEditOrganization(t *testing.T) {
+	target := "/api/organizations/" + handlertest.OrganizationId.String() + "/edit"
+
+	tests := []struct {
+		name    string
+		as      string
+		target  string
+		body    string
+		editErr error
+		code    int
+		errMsg  string
+	}{
+		{name: "edited", target: target, body: `{"name": "New name", "type": "JSC"}`, code: http.StatusOK},
+		{name: "malformed body", target: target, body: `{"name":`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "unknown type", target: target, body: `{"type": "Ltd"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid id", target: "/api/organizations/42/edit", body: `{"name": "New name"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "not found", target: "/api/organizations/" + handlertest.TenderId.String() + "/edit", body: `{"name": "New name"}`, code: http.StatusNotFound, errMsg: "organization not found"},
+		{name: "storage failure", target: target, body: `{"name": "New name"}`, editErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to edit organization"},
+		{name: "not allowed", as: "user3", target: target, body: `{"name": "New name"}`, code: http.StatusForbidden, errMsg: "user is not allowed to edit organization"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				EditOrganizationFunc: func(_ context.Context, o internal.Organization, actor string) (internal.Organization, error) {
+					if tt.editErr != nil {
+						return internal.Organization{}, tt.editErr
+					}
+					if o.Id != handlertest.OrganizationId || o.Name != "New name" || o.Description != "" || actor != "user1" {
+						t.Fatalf("storage got organization %+v, actor %s", o, actor)
+					}
+
+					edited := handlertest.Organization()
+					edited.Name = o.Name
+					edited.Type = o.Type
+					return edited, nil
+				},
+			}
+
+			srv := handlertest.New(t).As(cmp.Or(tt.as, "user1")).
+				Handle(http.MethodPatch, "/api/organizations/{organizationId}/edit", orgedit.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPatch, tt.target, tt.body).WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var o internal.Organization
+			resp.Decode(&o)
+			if o.Name != "New name" || o.Type != "JSC" || o.Description != handlertest.Organization().Description {
+				t.Fatalf("got organization %+v", o)
+			}
+		})
+	}
+}
@@ -1,17 +1,20 @@
 package tndedit
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"log/slog"
 	"net/http"
-	"strconv"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
-	"tender-app-backend/src/internal/storage"
+	"time"
 )
 
 type Request struct {
@@ -19,14 +22,15 @@ type Request struct {
 }
 
 type TenderEditor interface {
-	EditTender(t internal.Tender, editId int) (internal.Tender, error)
+	authz.Facts
+	EditTender(ctx context.Context, t internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error)
 }
 
 func New(log *slog.Logger, tenderEditor TenderEditor) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.edit.tndedit.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -35,60 +39,65 @@ func New(log *slog.Logger, tenderEditor TenderEditor) http.HandlerFunc {
 
 		err := render.DecodeJSON(r.Body, &req.Tender)
 		if err != nil {
-			log.Error("failed to decode request body", sl.Err(err))
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		tenderIdStr := chi.URLParam(r, "tenderId")
-		if tenderIdStr == "" {
-			log.Info("tender id is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		tenderId, err := uuid.Parse(chi.URLParam(r, "tenderId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		tenderId, err := strconv.Atoi(tenderIdStr)
+		expected, err := etag.Expected(r, req.Tender.Version)
 		if err != nil {
-			log.Info("failed to parse tender id")
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		if req.Tender.BidDeadline != nil && !req.Tender.BidDeadline.After(time.Now()) {
+			response.Fail(w, r, log, response.BadRequest(errors.New("bidDeadline must be in the future")))
+
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
+		if err != nil {
+			response.Fail(w, r, log, err)
 
 			return
 		}
 
-		tender, err := tenderEditor.EditTender(req.Tender, tenderId)
+		current, err := tenderEditor.GetTender(r.Context(), tenderId)
 		if err != nil {
-			if errors.Is(err, storage.ErrTenderNotFound) {
-				log.Info(
-					"tender not found",
-					slog.String("tender_id", tenderIdStr),
-				)
+			response.Fail(w, r, log, response.FromStorage(err, "edit tender"))
 
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, response.Error("tender not found"))
+			return
+		}
 
-				return
-			}
+		err = authz.Check(r.Context(), log, tenderEditor, authz.EditTender, username, authz.Tender(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "edit tender"))
 
-			log.Error("failed to edit tender", sl.Err(err))
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to edit tender"))
+		tender, err := tenderEditor.EditTender(r.Context(), req.Tender, tenderId, expected, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "edit tender"))
 
 			return
 		}
 
 		log.Info("tender edited", slog.Any("tender", tender))
 
+		etag.Set(w, tender.Version)
+
 		render.JSON(w, r, tender)
 	}
 }
@@ -0,0 +1,93 @@
+package tndedit_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/edit/tndedit"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestEditTender(t *testing.T) {
+	target := "/api/tenders/" + handlertest.TenderId.String() + "/edit"
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		ifMatch   string
+		expected  int
+		body      string
+		editErr   error
+		code      int
+		errMsg    string
+	}{
+		{name: "edited", target: target, body: `{"name": "New name"}`, code: http.StatusOK},
+		{name: "with bid deadline", target: target, body: `{"name": "New name", "bidDeadline": "2100-01-01T00:00:00Z"}`, code: http.StatusOK},
+		{name: "bid deadline passed", target: target, body: `{"name": "New name", "bidDeadline": "2024-01-01T00:00:00Z"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "malformed body", target: target, body: `{"name":`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid id", target: "/api/tenders/42/edit", body: `{"name": "New name"}`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "tender not found", target: target, body: `{"name": "New name"}`, editErr: storage.ErrTenderNotFound, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "storage failure", target: target, body: `{"name": "New name"}`, editErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to edit tender"},
+		{name: "expected version in body", target: target, body: `{"name": "New name", "version": 1}`, expected: 1, code: http.StatusOK},
+		{name: "expected version in If-Match", target: target, body: `{"name": "New name"}`, ifMatch: `"1"`, expected: 1, code: http.StatusOK},
+		{name: "If-Match and body disagree", target: target, body: `{"name": "New name", "version": 1}`, ifMatch: `"2"`, code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "malformed If-Match", target: target, body: `{"name": "New name"}`, ifMatch: "1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "version conflict", target: target, body: `{"name": "New name"}`, ifMatch: `"1"`, expected: 1, editErr: storage.ErrVersionConflict, code: http.StatusConflict, errMsg: "version is not the current one"},
+		{name: "anonymous", target: target, body: `{"name": "New name"}`, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "not allowed", as: "user3", target: target, body: `{"name": "New name"}`, code: http.StatusForbidden, errMsg: "user is not allowed to edit tender"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				EditTenderFunc: func(_ context.Context, tender internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error) {
+					if username != cmp.Or(tt.as, "user1") {
+						t.Fatalf("storage got author %q", username)
+					}
+					if expected != tt.expected {
+						t.Fatalf("storage got expected version %d, want %d", expected, tt.expected)
+					}
+					if tt.editErr != nil {
+						return internal.Tender{}, tt.editErr
+					}
+					if editId != handlertest.TenderId || tender.Name != "New name" {
+						t.Fatalf("storage got tender %+v, id %s", tender, editId)
+					}
+
+					edited := handlertest.Tender()
+					edited.Name = tender.Name
+					edited.Version = 2
+					return edited, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).WithHeader("If-Match", tt.ifMatch).Handle(http.MethodPatch, "/api/tenders/{tenderId}/edit", tndedit.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPatch, tt.target, tt.body).WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			resp.WantHeader("ETag", `"2"`)
+
+			var tender internal.Tender
+			resp.Decode(&tender)
+			if tender.Name != "New name" || tender.Version != 2 {
+				t.Fatalf("got tender %+v", tender)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package bidfeedback
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
+	"tender-app-backend/src/internal/lib/api/response"
+	"unicode/utf8"
+)
+
+type FeedbackSubmitter interface {
+	authz.Facts
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	SubmitBidFeedback(ctx context.Context, bidId uuid.UUID, feedback, username string) (internal.Bid, error)
+}
+
+func New(log *slog.Logger, feedbackSubmitter FeedbackSubmitter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.feedback.bidfeedback.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		bidId, err := uuid.Parse(chi.URLParam(r, "bidId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		feedback := r.URL.Query().Get("bidFeedback")
+		if feedback == "" || utf8.RuneCountInString(feedback) > internal.MaxFeedbackLength {
+			response.Fail(w, r, log, response.BadRequest(errors.New("invalid bid feedback")))
+
+			return
+		}
+
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := feedbackSubmitter.GetBid(r.Context(), bidId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "submit bid feedback"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, feedbackSubmitter, authz.BidFeedback, username, authz.Bid(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "submit bid feedback"))
+
+			return
+		}
+
+		bid, err := feedbackSubmitter.SubmitBidFeedback(r.Context(), bidId, feedback, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "submit bid feedback"))
+
+			return
+		}
+
+		log.Info("bid feedback submitted", slog.Any("bid", bid))
+
+		etag.Set(w, bid.Version)
+
+		render.JSON(w, r, bid)
+	}
+}
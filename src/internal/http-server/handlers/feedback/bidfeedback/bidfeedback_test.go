@@ -0,0 +1,88 @@
+package bidfeedback_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/feedback/bidfeedback"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestSubmitBidFeedback(t *testing.T) {
+	prefix := "/api/bids/" + handlertest.BidId.String() + "/feedback"
+	target := prefix + "?bidFeedback=Too+slow&username=user1"
+
+	tests := []struct {
+		name        string
+		as          string
+		anonymous   bool
+		target      string
+		status      string
+		feedbackErr error
+		code        int
+		errMsg      string
+	}{
+		{name: "submitted", target: target, code: http.StatusOK},
+		{name: "invalid id", target: "/api/bids/42/feedback?bidFeedback=Too+slow&username=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "no feedback", target: prefix + "?username=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "feedback too long", target: prefix + "?username=user1&bidFeedback=" + strings.Repeat("a", internal.MaxFeedbackLength+1), code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "username from token", target: prefix + "?bidFeedback=Too+slow", code: http.StatusOK},
+		{name: "bid not found", target: target, feedbackErr: storage.ErrBidNotFound, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "not responsible", target: target, feedbackErr: storage.ErrOrgRespNotFound, code: http.StatusForbidden, errMsg: "user is not allowed to submit bid feedback"},
+		{name: "storage failure", target: target, feedbackErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to submit bid feedback"},
+		{name: "anonymous", target: target, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other user", target: prefix + "?bidFeedback=Too+slow&username=user2", code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+		{name: "draft", target: target, status: internal.BidCreated, code: http.StatusForbidden, errMsg: "user is not allowed to submit bid feedback"},
+		{name: "not allowed", as: "user3", target: prefix + "?bidFeedback=Too+slow", code: http.StatusForbidden, errMsg: "user is not allowed to submit bid feedback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				// Only submitted bids take feedback.
+				GetBidFunc: func(_ context.Context, bidId uuid.UUID) (internal.Bid, error) {
+					if bidId != handlertest.BidId {
+						return internal.Bid{}, storage.ErrBidNotFound
+					}
+					bid := handlertest.Bid()
+					bid.Status = cmp.Or(tt.status, internal.BidPublished)
+					return bid, nil
+				},
+				SubmitBidFeedbackFunc: func(_ context.Context, bidId uuid.UUID, feedback, username string) (internal.Bid, error) {
+					if tt.feedbackErr != nil {
+						return internal.Bid{}, tt.feedbackErr
+					}
+					if bidId != handlertest.BidId || feedback != "Too slow" || username != "user1" {
+						t.Fatalf("storage got bid %s, feedback %q, username %q", bidId, feedback, username)
+					}
+					return handlertest.Bid(), nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodPut, "/api/bids/{bidId}/feedback", bidfeedback.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPut, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var bid internal.Bid
+			resp.Decode(&bid)
+			if bid != handlertest.Bid() {
+				t.Fatalf("got bid %+v", bid)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package bidget_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/all/bidget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestGetTenderBids(t *testing.T) {
+	target := "/api/bids/" + handlertest.TenderId.String() + "/list?username=user1&limit=1"
+
+	tests := []struct {
+		name    string
+		target  string
+		listErr error
+		code    int
+		errMsg  string
+	}{
+		{name: "bids", target: target, code: http.StatusOK},
+		{name: "invalid id", target: "/api/bids/42/list?username=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid page", target: "/api/bids/" + handlertest.TenderId.String() + "/list?limit=-1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "tender not found", target: target, listErr: storage.ErrTenderNotFound, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "hidden tender", target: target, listErr: storage.ErrOrgRespNotFound, code: http.StatusForbidden, errMsg: "user is not allowed to get tender bids"},
+		{name: "storage failure", target: target, listErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get tender bids"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetTenderBidsListFunc: func(_ context.Context, v internal.Viewer, tenderId uuid.UUID, page internal.Page) ([]internal.Bid, error) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					if v.Username != "user1" || tenderId != handlertest.TenderId || page != (internal.Page{Limit: 1}) {
+						t.Fatalf("storage got viewer %+v, tender %s, page %+v", v, tenderId, page)
+					}
+					return []internal.Bid{handlertest.Bid()}, nil
+				},
+			}
+
+			srv := handlertest.New(t).As("user1").Handle(http.MethodGet, "/api/bids/{tenderId}/list", bidget.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var bids []internal.Bid
+			resp.Decode(&bids)
+			if len(bids) != 1 || bids[0] != handlertest.Bid() {
+				t.Fatalf("got bids %+v", bids)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package empsget
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type EmployeesGetter interface {
+	GetEmployeesList(ctx context.Context, page internal.Page) ([]internal.Employee, error)
+}
+
+func New(log *slog.Logger, employeesGetter EmployeesGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.get-list.all.empsget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		page, err := paging.Parse(r)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		_, err = auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		res, err := employeesGetter.GetEmployeesList(r.Context(), page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get employees list"))
+
+			return
+		}
+
+		render.JSON(w, r, res)
+	}
+}
@@ -0,0 +1,64 @@
+package empsget_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/all/empsget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestGetEmployees(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous bool
+		target    string
+		listErr   error
+		page      internal.Page
+		code      int
+		errMsg    string
+	}{
+		{name: "default page", target: "/api/employees", page: internal.Page{Limit: 5}, code: http.StatusOK},
+		{name: "paged", target: "/api/employees?limit=2&offset=4", page: internal.Page{Limit: 2, Offset: 4}, code: http.StatusOK},
+		{name: "invalid limit", target: "/api/employees?limit=51", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "storage failure", target: "/api/employees", listErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get employees list"},
+		{name: "anonymous", anonymous: true, target: "/api/employees", code: http.StatusUnauthorized, errMsg: "authentication required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetEmployeesListFunc: func(_ context.Context, page internal.Page) ([]internal.Employee, error) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					if page != tt.page {
+						t.Fatalf("storage got page %+v", page)
+					}
+					return []internal.Employee{handlertest.Employee()}, nil
+				},
+			}
+
+			as := "user3"
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodGet, "/api/employees", empsget.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var got []internal.Employee
+			resp.Decode(&got)
+			if len(got) != 1 || got[0] != handlertest.Employee() {
+				t.Fatalf("got %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package orgsget
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type OrganizationsGetter interface {
+	GetOrganizationsList(ctx context.Context, page internal.Page) ([]internal.Organization, error)
+}
+
+func New(log *slog.Logger, organizationsGetter OrganizationsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.get-list.all.orgsget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		page, err := paging.Parse(r)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		_, err = auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		res, err := organizationsGetter.GetOrganizationsList(r.Context(), page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get organizations list"))
+
+			return
+		}
+
+		render.JSON(w, r, res)
+	}
+}
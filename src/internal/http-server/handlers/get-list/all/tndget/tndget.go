@@ -1,34 +1,43 @@
 package tndget
 
 import (
+	"context"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
 )
 
 type TenderGetter interface {
-	GetTendersList() ([]internal.Tender, error)
+	GetTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error)
 }
 
 func New(log *slog.Logger, tenderGetter TenderGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.get-list.all.tndget.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		res, err := tenderGetter.GetTendersList()
+		page, err := paging.Parse(r)
 		if err != nil {
-			log.Error("failed to get tenders list", sl.Err(err))
+			response.Fail(w, r, log, response.BadRequest(err))
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to get tenders list"))
+			return
+		}
+
+		serviceTypes := r.URL.Query()["service_type"]
+		viewer := auth.Viewer(r)
+
+		res, err := tenderGetter.GetTendersList(r.Context(), viewer, serviceTypes, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get tenders list"))
 
 			return
 		}
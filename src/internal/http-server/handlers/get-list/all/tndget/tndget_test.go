@@ -0,0 +1,68 @@
+package tndget_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/all/tndget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestGetTenders(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		listErr      error
+		viewer       string
+		serviceTypes []string
+		page         internal.Page
+		code         int
+		errMsg       string
+	}{
+		{name: "default page", target: "/api/tenders", page: internal.Page{Limit: 5}, code: http.StatusOK},
+		{
+			name:         "filtered",
+			target:       "/api/tenders?username=user1&service_type=Delivery&service_type=Construction&limit=2&offset=4",
+			viewer:       "user1",
+			serviceTypes: []string{"Delivery", "Construction"},
+			page:         internal.Page{Limit: 2, Offset: 4},
+			code:         http.StatusOK,
+		},
+		{name: "invalid limit", target: "/api/tenders?limit=51", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid offset", target: "/api/tenders?offset=-1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "storage failure", target: "/api/tenders", listErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get tenders list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetTendersListFunc: func(_ context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					if v.Username != tt.viewer || !slices.Equal(serviceTypes, tt.serviceTypes) || page != tt.page {
+						t.Fatalf("storage got viewer %+v, service types %v, page %+v", v, serviceTypes, page)
+					}
+					return []internal.Tender{handlertest.Tender()}, nil
+				},
+			}
+
+			srv := handlertest.New(t).As(tt.viewer).Handle(http.MethodGet, "/api/tenders", tndget.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var tenders []internal.Tender
+			resp.Decode(&tenders)
+			if len(tenders) != 1 || tenders[0] != handlertest.Tender() {
+				t.Fatalf("got tenders %+v", tenders)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package auditget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
+	"tender-app-backend/src/internal/lib/api/response"
+	"time"
+)
+
+type AuditGetter interface {
+	authz.Facts
+	GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error)
+	GetAuditEvents(ctx context.Context, f internal.AuditFilter, page internal.Page) ([]internal.AuditEvent, error)
+}
+
+// New lists the audit log of an organization, newest first. Only its
+// responsibles may read it.
+func New(log *slog.Logger, auditGetter AuditGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.get-list.audit.auditget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		filter, err := parseFilter(r)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		page, err := paging.Parse(r)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		organization, err := auditGetter.GetOrganization(r.Context(), filter.OrganizationId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view audit"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, auditGetter, authz.ViewAudit, username, authz.Organization(organization))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view audit"))
+
+			return
+		}
+
+		res, err := auditGetter.GetAuditEvents(r.Context(), filter, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view audit"))
+
+			return
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+// entityTypes are the entity types the audit log can be filtered by.
+var entityTypes = map[string]bool{
+	internal.EntityTender:       true,
+	internal.EntityBid:          true,
+	internal.EntityEmployee:     true,
+	internal.EntityOrganization: true,
+	internal.EntityResponsible:  true,
+}
+
+// parseFilter reads the organizationId, entityType, entityId, from and to
+// query parameters. Only the organization is required, times are RFC 3339.
+func parseFilter(r *http.Request) (internal.AuditFilter, error) {
+	q := r.URL.Query()
+
+	var (
+		f   internal.AuditFilter
+		err error
+	)
+
+	f.OrganizationId, err = uuid.Parse(q.Get("organizationId"))
+	if err != nil {
+		return internal.AuditFilter{}, fmt.Errorf("organizationId: %w", err)
+	}
+
+	f.EntityType = q.Get("entityType")
+	if f.EntityType != "" && !entityTypes[f.EntityType] {
+		return internal.AuditFilter{}, fmt.Errorf("entityType: unknown type %q", f.EntityType)
+	}
+
+	if entityId := q.Get("entityId"); entityId != "" {
+		f.EntityId, err = uuid.Parse(entityId)
+		if err != nil {
+			return internal.AuditFilter{}, fmt.Errorf("entityId: %w", err)
+		}
+	}
+
+	if from := q.Get("from"); from != "" {
+		f.From, err = time.Parse(time.RFC3339, from)
+		if err != nil {
+			return internal.AuditFilter{}, fmt.Errorf("from: %w", err)
+		}
+	}
+
+	if to := q.Get("to"); to != "" {
+		f.To, err = time.Parse(time.RFC3339, to)
+		if err != nil {
+			return internal.AuditFilter{}, fmt.Errorf("to: %w", err)
+		}
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return internal.AuditFilter{}, errors.New("from must be before to")
+	}
+
+	return f, nil
+}
@@ -0,0 +1,97 @@
+package auditget_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/audit/auditget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+	"time"
+)
+
+func TestGetAuditEvents(t *testing.T) {
+	prefix := "/api/audit?organizationId=" + handlertest.OrganizationId.String()
+	target := prefix + "&entityType=tender&entityId=" + handlertest.TenderId.String() +
+		"&from=2024-09-01T00:00:00Z&to=2024-09-02T03:00:00%2B03:00&limit=10&offset=5"
+
+	all := internal.AuditFilter{
+		OrganizationId: handlertest.OrganizationId,
+		EntityType:     internal.EntityTender,
+		EntityId:       handlertest.TenderId,
+		From:           time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		filter    internal.AuditFilter
+		page      internal.Page
+		getErr    error
+		code      int
+		errMsg    string
+	}{
+		{name: "all filters", target: target, filter: all, page: internal.Page{Limit: 10, Offset: 5}, code: http.StatusOK},
+		{name: "organization only", target: prefix, filter: internal.AuditFilter{OrganizationId: handlertest.OrganizationId}, page: internal.Page{Limit: 5}, code: http.StatusOK},
+		{name: "no organization", target: "/api/audit", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid organization", target: "/api/audit?organizationId=42", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "employee events", target: prefix + "&entityType=employee", filter: internal.AuditFilter{OrganizationId: handlertest.OrganizationId, EntityType: internal.EntityEmployee}, page: internal.Page{Limit: 5}, code: http.StatusOK},
+		{name: "unknown entity type", target: prefix + "&entityType=invoice", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid entity id", target: prefix + "&entityId=42", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid from", target: prefix + "&from=yesterday", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "to before from", target: prefix + "&from=2024-09-02T00:00:00Z&to=2024-09-01T00:00:00Z", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid page", target: prefix + "&limit=-1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "organization not found", target: "/api/audit?organizationId=" + uuid.NewString(), code: http.StatusNotFound, errMsg: "organization not found"},
+		{name: "storage failure", target: prefix, filter: internal.AuditFilter{OrganizationId: handlertest.OrganizationId}, page: internal.Page{Limit: 5}, getErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to view audit"},
+		{name: "anonymous", target: prefix, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "not allowed", as: "user3", target: prefix, code: http.StatusForbidden, errMsg: "user is not allowed to view audit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetAuditEventsFunc: func(_ context.Context, f internal.AuditFilter, page internal.Page) ([]internal.AuditEvent, error) {
+					if tt.getErr != nil {
+						return nil, tt.getErr
+					}
+					if f.OrganizationId != tt.filter.OrganizationId || f.EntityType != tt.filter.EntityType || f.EntityId != tt.filter.EntityId ||
+						!f.From.Equal(tt.filter.From) || !f.To.Equal(tt.filter.To) {
+						t.Fatalf("storage got filter %+v, want %+v", f, tt.filter)
+					}
+					if page != tt.page {
+						t.Fatalf("storage got page %+v", page)
+					}
+					return []internal.AuditEvent{handlertest.AuditEvent()}, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodGet, "/api/audit", auditget.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var events []internal.AuditEvent
+			resp.Decode(&events)
+			want := handlertest.AuditEvent()
+			if len(events) != 1 || events[0].Id != want.Id || events[0].Actor != want.Actor || events[0].Action != want.Action ||
+				string(events[0].Before) != string(want.Before) || string(events[0].After) != string(want.After) ||
+				events[0].RequestId != want.RequestId || !events[0].CreatedAt.Equal(want.CreatedAt) {
+				t.Fatalf("got events %+v", events)
+			}
+		})
+	}
+}
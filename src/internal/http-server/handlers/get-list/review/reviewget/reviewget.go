@@ -0,0 +1,84 @@
+package reviewget
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type ReviewGetter interface {
+	authz.Facts
+	GetBidReviews(ctx context.Context, tenderId uuid.UUID, authorUsername, requesterUsername string, page internal.Page) ([]internal.Review, error)
+}
+
+func New(log *slog.Logger, reviewGetter ReviewGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.get-list.review.reviewget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		tenderId, err := uuid.Parse(chi.URLParam(r, "tenderId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		authorUsername := r.URL.Query().Get("authorUsername")
+		if authorUsername == "" {
+			response.Fail(w, r, log, response.BadRequest(errors.New("username is empty")))
+
+			return
+		}
+
+		requesterUsername, err := auth.Actor(r, r.URL.Query().Get("requesterUsername"))
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		page, err := paging.Parse(r)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		current, err := reviewGetter.GetTender(r.Context(), tenderId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get bid reviews"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, reviewGetter, authz.ViewBidReviews, requesterUsername, authz.Tender(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get bid reviews"))
+
+			return
+		}
+
+		res, err := reviewGetter.GetBidReviews(r.Context(), tenderId, authorUsername, requesterUsername, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get bid reviews"))
+
+			return
+		}
+
+		render.JSON(w, r, res)
+	}
+}
@@ -0,0 +1,81 @@
+package reviewget_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/review/reviewget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestGetBidReviews(t *testing.T) {
+	prefix := "/api/bids/" + handlertest.TenderId.String() + "/reviews"
+	target := prefix + "?authorUsername=user2&requesterUsername=user1&limit=10&offset=5"
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		reviewErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "reviews", target: target, code: http.StatusOK},
+		{name: "invalid id", target: "/api/bids/42/reviews?authorUsername=user2&requesterUsername=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "no author", target: prefix + "?requesterUsername=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "requester from token", target: prefix + "?authorUsername=user2&limit=10&offset=5", code: http.StatusOK},
+		{name: "invalid page", target: prefix + "?authorUsername=user2&requesterUsername=user1&limit=100", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "tender not found", target: target, reviewErr: storage.ErrTenderNotFound, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "author bids not found", target: target, reviewErr: storage.ErrBidNotFound, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "unknown user", target: target, reviewErr: storage.ErrUserNotFound, code: http.StatusUnauthorized, errMsg: "user does not exist"},
+		{name: "not responsible", target: target, reviewErr: storage.ErrOrgRespNotFound, code: http.StatusForbidden, errMsg: "user is not allowed to get bid reviews"},
+		{name: "storage failure", target: target, reviewErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get bid reviews"},
+		{name: "anonymous", target: target, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other requester", target: prefix + "?authorUsername=user2&requesterUsername=user2", code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+		{name: "not allowed", as: "user3", target: prefix + "?authorUsername=user2", code: http.StatusForbidden, errMsg: "user is not allowed to get bid reviews"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetBidReviewsFunc: func(_ context.Context, tenderId uuid.UUID, authorUsername, requesterUsername string, page internal.Page) ([]internal.Review, error) {
+					if tt.reviewErr != nil {
+						return nil, tt.reviewErr
+					}
+					if tenderId != handlertest.TenderId || authorUsername != "user2" || requesterUsername != "user1" {
+						t.Fatalf("storage got tender %s, author %q, requester %q", tenderId, authorUsername, requesterUsername)
+					}
+					if page != (internal.Page{Limit: 10, Offset: 5}) {
+						t.Fatalf("storage got page %+v", page)
+					}
+					return []internal.Review{handlertest.Review()}, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodGet, "/api/bids/{tenderId}/reviews", reviewget.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var reviews []internal.Review
+			resp.Decode(&reviews)
+			if len(reviews) != 1 || reviews[0] != handlertest.Review() {
+				t.Fatalf("got reviews %+v", reviews)
+			}
+		})
+	}
+}
@@ -1,21 +1,24 @@
 package bidstatus
 
 import (
+	"context"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"log/slog"
 	"net/http"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
 )
 
 type BidStatusGetter interface {
-	GetBidsList() ([]internal.Bid, error)
+	GetBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error)
 }
 
 type Response struct {
-	Id     int
+	Id     uuid.UUID
 	Status string
 }
 
@@ -23,19 +26,25 @@ func New(log *slog.Logger, bidGetter BidStatusGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.get-list.status.bidstatus.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
 		resp := make([]Response, 0)
 
-		res, err := bidGetter.GetBidsList()
+		page, err := paging.Parse(r)
 		if err != nil {
-			log.Error("failed to get bids status list", sl.Err(err))
+			response.Fail(w, r, log, response.BadRequest(err))
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to get bids status list"))
+			return
+		}
+
+		viewer := auth.Viewer(r)
+
+		res, err := bidGetter.GetBidsList(r.Context(), viewer, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get bids status list"))
 
 			return
 		}
@@ -0,0 +1,56 @@
+package bidstatus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/status/bidstatus"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestGetBidsStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		listErr error
+		code    int
+		errMsg  string
+	}{
+		{name: "statuses", target: "/api/bids/status?username=user2", code: http.StatusOK},
+		{name: "invalid page", target: "/api/bids/status?offset=x", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "storage failure", target: "/api/bids/status", listErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get bids status list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetBidsListFunc: func(_ context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					if v.Username != "user2" {
+						t.Fatalf("storage got viewer %+v", v)
+					}
+					return []internal.Bid{handlertest.Bid()}, nil
+				},
+			}
+
+			srv := handlertest.New(t).As("user2").Handle(http.MethodGet, "/api/bids/status", bidstatus.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var statuses []bidstatus.Response
+			resp.Decode(&statuses)
+			want := bidstatus.Response{Id: handlertest.BidId, Status: internal.BidCreated}
+			if len(statuses) != 1 || statuses[0] != want {
+				t.Fatalf("got statuses %+v", statuses)
+			}
+		})
+	}
+}
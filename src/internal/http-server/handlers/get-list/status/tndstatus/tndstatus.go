@@ -1,21 +1,24 @@
 package tndstatus
 
 import (
+	"context"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"log/slog"
 	"net/http"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
 )
 
 type TenderStatusGetter interface {
-	GetTendersList() ([]internal.Tender, error)
+	GetTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error)
 }
 
 type Response struct {
-	Id     int
+	Id     uuid.UUID
 	Status string
 }
 
@@ -23,19 +26,26 @@ func New(log *slog.Logger, tenderGetter TenderStatusGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.get-list.status.tndstatus.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
 		resp := make([]Response, 0)
 
-		res, err := tenderGetter.GetTendersList()
+		page, err := paging.Parse(r)
 		if err != nil {
-			log.Error("failed to get tenders status list", sl.Err(err))
+			response.Fail(w, r, log, response.BadRequest(err))
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to get tenders status list"))
+			return
+		}
+
+		serviceTypes := r.URL.Query()["service_type"]
+		viewer := auth.Viewer(r)
+
+		res, err := tenderGetter.GetTendersList(r.Context(), viewer, serviceTypes, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get tenders status list"))
 
 			return
 		}
@@ -0,0 +1,56 @@
+package tndstatus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/status/tndstatus"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestGetTendersStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		listErr error
+		code    int
+		errMsg  string
+	}{
+		{name: "statuses", target: "/api/tenders/status?username=user1", code: http.StatusOK},
+		{name: "invalid page", target: "/api/tenders/status?limit=51", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "storage failure", target: "/api/tenders/status", listErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get tenders status list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetTendersListFunc: func(_ context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					if v.Username != "user1" {
+						t.Fatalf("storage got viewer %+v", v)
+					}
+					return []internal.Tender{handlertest.Tender()}, nil
+				},
+			}
+
+			srv := handlertest.New(t).As("user1").Handle(http.MethodGet, "/api/tenders/status", tndstatus.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var statuses []tndstatus.Response
+			resp.Decode(&statuses)
+			want := tndstatus.Response{Id: handlertest.TenderId, Status: internal.TenderCreated}
+			if len(statuses) != 1 || statuses[0] != want {
+				t.Fatalf("got statuses %+v", statuses)
+			}
+		})
+	}
+}
@@ -1,44 +1,47 @@
 package userbidget
 
 import (
+	"context"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
 )
 
 type UserBidGetter interface {
-	GetUserBidsList(username string) ([]internal.Bid, error)
+	GetUserBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error)
 }
 
 func New(log *slog.Logger, bidGetter UserBidGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.get-list.user.userbidget.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		username := r.URL.Query().Get("username")
-		if username == "" {
-			log.Info("username is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
+		if err != nil {
+			response.Fail(w, r, log, err)
 
 			return
 		}
 
-		res, err := bidGetter.GetUserBidsList(username)
+		page, err := paging.Parse(r)
 		if err != nil {
-			log.Error("failed to get user bids list", sl.Err(err))
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to get user bids list"))
+		res, err := bidGetter.GetUserBidsList(r.Context(), internal.Viewer{Username: username}, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get user bids list"))
 
 			return
 		}
@@ -0,0 +1,62 @@
+package userbidget_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/user/userbidget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestGetUserBids(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous bool
+		target    string
+		listErr   error
+		code      int
+		errMsg    string
+	}{
+		{name: "bids", target: "/api/bids/my?username=user2&offset=1", code: http.StatusOK},
+		{name: "username from token", target: "/api/bids/my?offset=1", code: http.StatusOK},
+		{name: "invalid page", target: "/api/bids/my?username=user2&offset=x", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "storage failure", target: "/api/bids/my?username=user2&offset=1", listErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get user bids list"},
+		{name: "anonymous", target: "/api/bids/my", anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other user", target: "/api/bids/my?username=user1", code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetUserBidsListFunc: func(_ context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					if v.Username != "user2" || page != (internal.Page{Limit: 5, Offset: 1}) {
+						t.Fatalf("storage got viewer %+v, page %+v", v, page)
+					}
+					return []internal.Bid{handlertest.Bid()}, nil
+				},
+			}
+
+			srv := handlertest.New(t).As("user2").Handle(http.MethodGet, "/api/bids/my", userbidget.New(handlertest.Logger(), fake))
+			if tt.anonymous {
+				srv.As("")
+			}
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var bids []internal.Bid
+			resp.Decode(&bids)
+			if len(bids) != 1 || bids[0] != handlertest.Bid() {
+				t.Fatalf("got bids %+v", bids)
+			}
+		})
+	}
+}
@@ -1,44 +1,49 @@
 package usertndget
 
 import (
+	"context"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
 )
 
 type UserTenderGetter interface {
-	GetUserTendersList(username string) ([]internal.Tender, error)
+	GetUserTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error)
 }
 
 func New(log *slog.Logger, tenderGetter UserTenderGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.get-list.user.usertndget.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		username := r.URL.Query().Get("username")
-		if username == "" {
-			log.Info("username is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
+		if err != nil {
+			response.Fail(w, r, log, err)
 
 			return
 		}
 
-		res, err := tenderGetter.GetUserTendersList(username)
+		page, err := paging.Parse(r)
 		if err != nil {
-			log.Error("failed to get user tenders list", sl.Err(err))
+			response.Fail(w, r, log, response.BadRequest(err))
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to get user tenders list"))
+			return
+		}
+
+		serviceTypes := r.URL.Query()["service_type"]
+
+		res, err := tenderGetter.GetUserTendersList(r.Context(), internal.Viewer{Username: username}, serviceTypes, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get user tenders list"))
 
 			return
 		}
@@ -0,0 +1,62 @@
+package usertndget_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get-list/user/usertndget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestGetUserTenders(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous bool
+		target    string
+		listErr   error
+		code      int
+		errMsg    string
+	}{
+		{name: "tenders", target: "/api/tenders/my?username=user1&limit=3", code: http.StatusOK},
+		{name: "username from token", target: "/api/tenders/my?limit=3", code: http.StatusOK},
+		{name: "invalid page", target: "/api/tenders/my?username=user1&limit=x", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "storage failure", target: "/api/tenders/my?username=user1&limit=3", listErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get user tenders list"},
+		{name: "anonymous", target: "/api/tenders/my", anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other user", target: "/api/tenders/my?username=user2", code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetUserTendersListFunc: func(_ context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					if v.Username != "user1" || page != (internal.Page{Limit: 3}) {
+						t.Fatalf("storage got viewer %+v, page %+v", v, page)
+					}
+					return []internal.Tender{handlertest.Tender()}, nil
+				},
+			}
+
+			srv := handlertest.New(t).As("user1").Handle(http.MethodGet, "/api/tenders/my", usertndget.New(handlertest.Logger(), fake))
+			if tt.anonymous {
+				srv.As("")
+			}
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var tenders []internal.Tender
+			resp.Decode(&tenders)
+			if len(tenders) != 1 || tenders[0] != handlertest.Tender() {
+				t.Fatalf("got tenders %+v", tenders)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package empget
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type EmployeeGetter interface {
+	GetEmployee(ctx context.Context, id uuid.UUID) (internal.Employee, error)
+}
+
+func New(log *slog.Logger, employeeGetter EmployeeGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.get.empget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		employeeId, err := uuid.Parse(chi.URLParam(r, "employeeId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		_, err = auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		employee, err := employeeGetter.GetEmployee(r.Context(), employeeId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromEmployeeStorage(err, "get employee"))
+
+			return
+		}
+
+		render.JSON(w, r, employee)
+	}
+}
@@ -0,0 +1,52 @@
+package orgget
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type OrganizationGetter interface {
+	GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error)
+}
+
+func New(log *slog.Logger, organizationGetter OrganizationGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.get.orgget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		orgId, err := uuid.Parse(chi.URLParam(r, "organizationId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		_, err = auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		organization, err := organizationGetter.GetOrganization(r.Context(), orgId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get organization"))
+
+			return
+		}
+
+		render.JSON(w, r, organization)
+	}
+}
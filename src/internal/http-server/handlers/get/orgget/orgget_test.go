@@ -0,0 +1,51 @@
+package orgget_test
+
+import (
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/get/orgget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestGetOrganization(t *testing.T) {
+	target := "/api/organizations/" + handlertest.OrganizationId.String()
+
+	tests := []struct {
+		name      string
+		anonymous bool
+		target    string
+		code      int
+		errMsg    string
+	}{
+		{name: "found", target: target, code: http.StatusOK},
+		{name: "invalid id", target: "/api/organizations/42", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "not found", target: "/api/organizations/" + handlertest.TenderId.String(), code: http.StatusNotFound, errMsg: "organization not found"},
+		{name: "anonymous", anonymous: true, target: target, code: http.StatusUnauthorized, errMsg: "authentication required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := (&handlertest.Storage{}).WithFixtures()
+
+			as := "user3"
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodGet, "/api/organizations/{organizationId}", orgget.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var o internal.Organization
+			resp.Decode(&o)
+			if o != handlertest.Organization() {
+				t.Fatalf("got organization %+v", o)
+			}
+		})
+	}
+}
@@ -1,21 +1,15 @@
 package ping
 
 import (
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 )
 
+// New answers "ok". It has nothing to log, log is kept for the shape
+// every handler constructor shares.
 func New(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "handlers.ping.New"
-
-		log = log.With(
-			slog.String("op", op),
-			slog.String("request_id", middleware.GetReqID(r.Context())),
-		)
-
 		render.PlainText(w, r, "ok")
 	}
 }
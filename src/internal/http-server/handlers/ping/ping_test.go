@@ -0,0 +1,17 @@
+package ping_test
+
+import (
+	"net/http"
+	"tender-app-backend/src/internal/http-server/handlers/ping"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	srv := handlertest.New(t).Handle(http.MethodGet, "/api/ping", ping.New(handlertest.Logger()))
+
+	resp := srv.Do(http.MethodGet, "/api/ping", "").WantStatus(http.StatusOK)
+	if string(resp.Body) != "ok" {
+		t.Fatalf("got body %q, want ok", resp.Body)
+	}
+}
@@ -0,0 +1,73 @@
+package respadd
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type ResponsibleAdder interface {
+	authz.Facts
+	GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error)
+	AddOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error
+}
+
+// New makes the employee from the path a responsible of the organization.
+func New(log *slog.Logger, responsibleAdder ResponsibleAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.responsible.respadd.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		orgId, err := uuid.Parse(chi.URLParam(r, "organizationId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		employee := chi.URLParam(r, "username")
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		organization, err := responsibleAdder.GetOrganization(r.Context(), orgId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "add responsible"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, responsibleAdder, authz.ManageResponsibles, username, authz.Organization(organization))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "add responsible"))
+
+			return
+		}
+
+		err = responsibleAdder.AddOrganizationResponsible(r.Context(), orgId, employee, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromEmployeeStorage(err, "add responsible"))
+
+			return
+		}
+
+		log.Info("responsible added", slog.String("organization_id", orgId.String()), slog.String("employee", employee))
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
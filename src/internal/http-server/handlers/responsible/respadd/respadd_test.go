@@ -0,0 +1,62 @@
+package respadd_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal/http-server/handlers/responsible/respadd"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestAddResponsible(t *testing.T) {
+	target := "/api/organizations/" + handlertest.OrganizationId.String() + "/responsibles/user4"
+
+	tests := []struct {
+		name     string
+		as       string
+		target   string
+		storeErr error
+		code     int
+		errMsg   string
+	}{
+		{name: "done", target: target, code: http.StatusNoContent},
+		{name: "invalid id", target: "/api/organizations/42/responsibles/user4", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "not found", target: "/api/organizations/" + handlertest.TenderId.String() + "/responsibles/user4", code: http.StatusNotFound, errMsg: "organization not found"},
+		{name: "no such employee", target: target, storeErr: storage.ErrUserNotFound, code: http.StatusNotFound, errMsg: "employee not found"},
+		{name: "already responsible", target: target, storeErr: storage.ErrAlreadyExists, code: http.StatusConflict, errMsg: "already exists"},
+		{name: "storage failure", target: target, storeErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to add responsible"},
+		{name: "not allowed", as: "user3", target: target, code: http.StatusForbidden, errMsg: "user is not allowed to add responsible"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := false
+			fake := &handlertest.Storage{
+				AddOrganizationResponsibleFunc: func(_ context.Context, orgId uuid.UUID, username, actor string) error {
+					if orgId != handlertest.OrganizationId || username != "user4" || actor != "user1" {
+						t.Fatalf("storage got organization %s, username %s, actor %s", orgId, username, actor)
+					}
+					done = tt.storeErr == nil
+					return tt.storeErr
+				},
+			}
+
+			srv := handlertest.New(t).As(cmp.Or(tt.as, "user1")).
+				Handle(http.MethodPost, "/api/organizations/{organizationId}/responsibles/{username}", respadd.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPost, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			if !done {
+				t.Fatal("storage not called")
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package respget
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type ResponsiblesGetter interface {
+	GetOrganizationResponsibles(ctx context.Context, orgId uuid.UUID, page internal.Page) ([]internal.Employee, error)
+}
+
+func New(log *slog.Logger, responsiblesGetter ResponsiblesGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.responsible.respget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		orgId, err := uuid.Parse(chi.URLParam(r, "organizationId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		page, err := paging.Parse(r)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		_, err = auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		res, err := responsiblesGetter.GetOrganizationResponsibles(r.Context(), orgId, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get organization responsibles"))
+
+			return
+		}
+
+		render.JSON(w, r, res)
+	}
+}
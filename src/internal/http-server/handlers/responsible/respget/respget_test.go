@@ -0,0 +1,66 @@
+package respget_test
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/responsible/respget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestGetResponsibles(t *testing.T) {
+	target := "/api/organizations/" + handlertest.OrganizationId.String() + "/responsibles"
+
+	tests := []struct {
+		name      string
+		anonymous bool
+		target    string
+		page      internal.Page
+		code      int
+		errMsg    string
+	}{
+		{name: "default page", target: target, page: internal.Page{Limit: 5}, code: http.StatusOK},
+		{name: "paged", target: target + "?limit=1&offset=1", page: internal.Page{Limit: 1, Offset: 1}, code: http.StatusOK},
+		{name: "invalid id", target: "/api/organizations/42/responsibles", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "not found", target: "/api/organizations/" + handlertest.TenderId.String() + "/responsibles", code: http.StatusNotFound, errMsg: "organization not found"},
+		{name: "anonymous", anonymous: true, target: target, code: http.StatusUnauthorized, errMsg: "authentication required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetOrganizationResponsiblesFunc: func(_ context.Context, orgId uuid.UUID, page internal.Page) ([]internal.Employee, error) {
+					if orgId != handlertest.OrganizationId {
+						return nil, storage.ErrOrganizationNotFound
+					}
+					if page != tt.page {
+						t.Fatalf("storage got page %+v", page)
+					}
+					return []internal.Employee{handlertest.Employee()}, nil
+				},
+			}
+
+			as := "user3"
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodGet, "/api/organizations/{organizationId}/responsibles", respget.New(handlertest.Logger(), fake))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var employees []internal.Employee
+			resp.Decode(&employees)
+			if len(employees) != 1 || employees[0] != handlertest.Employee() {
+				t.Fatalf("got responsibles %+v", employees)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package respremove
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type ResponsibleRemover interface {
+	authz.Facts
+	GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error)
+	RemoveOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error
+}
+
+// New takes the responsibility away from the employee in the path. The last
+// responsible of an organization is kept, the request is answered with 409.
+func New(log *slog.Logger, responsibleRemover ResponsibleRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.responsible.respremove.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		orgId, err := uuid.Parse(chi.URLParam(r, "organizationId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		employee := chi.URLParam(r, "username")
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		organization, err := responsibleRemover.GetOrganization(r.Context(), orgId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "remove responsible"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, responsibleRemover, authz.ManageResponsibles, username, authz.Organization(organization))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "remove responsible"))
+
+			return
+		}
+
+		err = responsibleRemover.RemoveOrganizationResponsible(r.Context(), orgId, employee, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromEmployeeStorage(err, "remove responsible"))
+
+			return
+		}
+
+		log.Info("responsible removed", slog.String("organization_id", orgId.String()), slog.String("employee", employee))
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -1,97 +1,89 @@
 package bidrollback
 
 import (
-	"errors"
+	"context"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
-	"tender-app-backend/src/internal/storage"
 )
 
 type BidRollbacker interface {
-	RollbackBid(bidId, version int) (internal.Bid, error)
+	authz.Facts
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	RollbackBid(ctx context.Context, bidId uuid.UUID, version, expected int, username string) (internal.Bid, error)
 }
 
 func New(log *slog.Logger, bidRollbacker BidRollbacker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.rollback.bidrollback.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		bidIdStr := chi.URLParam(r, "bidId")
-		if bidIdStr == "" {
-			log.Info("bid id is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		bidId, err := uuid.Parse(chi.URLParam(r, "bidId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		bidId, err := strconv.Atoi(bidIdStr)
+		version, err := strconv.Atoi(chi.URLParam(r, "version"))
 		if err != nil {
-			log.Info("failed to parse bid id")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		versionStr := chi.URLParam(r, "version")
-		if versionStr == "" {
-			log.Info("version is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		expected, err := etag.Expected(r, 0)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		version, err := strconv.Atoi(versionStr)
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
 		if err != nil {
-			log.Info("failed to parse version")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+			response.Fail(w, r, log, err)
 
 			return
 		}
 
-		bid, err := bidRollbacker.RollbackBid(bidId, version)
+		current, err := bidRollbacker.GetBid(r.Context(), bidId)
 		if err != nil {
-			if errors.Is(err, storage.ErrBidNotFound) {
-				log.Info(
-					"bid not found",
-					slog.String("bid_id", bidIdStr),
-					slog.String("version", versionStr),
-				)
+			response.Fail(w, r, log, response.FromStorage(err, "roll back bid"))
 
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, response.Error("bid or its version not found"))
+			return
+		}
 
-				return
-			}
+		err = authz.Check(r.Context(), log, bidRollbacker, authz.RollbackBid, username, authz.Bid(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "roll back bid"))
 
-			log.Error("failed to roll back bid", sl.Err(err))
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to roll back bid"))
+		bid, err := bidRollbacker.RollbackBid(r.Context(), bidId, version, expected, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "roll back bid"))
 
 			return
 		}
 
 		log.Info("bid rolled back", slog.Any("bid", bid))
 
+		etag.Set(w, bid.Version)
+
 		render.JSON(w, r, bid)
 	}
 }
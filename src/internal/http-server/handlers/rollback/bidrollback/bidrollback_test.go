@@ -0,0 +1,87 @@
+package bidrollback_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/rollback/bidrollback"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestRollbackBid(t *testing.T) {
+	prefix := "/api/bids/" + handlertest.BidId.String() + "/rollback/"
+
+	tests := []struct {
+		name        string
+		as          string
+		anonymous   bool
+		target      string
+		ifMatch     string
+		expected    int
+		rollbackErr error
+		code        int
+		errMsg      string
+	}{
+		{name: "rolled back", target: prefix + "2", code: http.StatusOK},
+		{name: "invalid id", target: "/api/bids/42/rollback/2", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid version", target: prefix + "second", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "version not found", target: prefix + "2", rollbackErr: storage.ErrBidNotFound, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "storage failure", target: prefix + "2", rollbackErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to roll back bid"},
+		{name: "expected version in If-Match", target: prefix + "2", ifMatch: `"1"`, expected: 1, code: http.StatusOK},
+		{name: "malformed If-Match", target: prefix + "2", ifMatch: "1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "version conflict", target: prefix + "2", ifMatch: `"1"`, expected: 1, rollbackErr: storage.ErrVersionConflict, code: http.StatusConflict, errMsg: "version is not the current one"},
+		{name: "anonymous", target: prefix + "2", anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "not allowed", as: "user3", target: prefix + "2", code: http.StatusForbidden, errMsg: "user is not allowed to roll back bid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				RollbackBidFunc: func(_ context.Context, bidId uuid.UUID, version int, expected int, username string) (internal.Bid, error) {
+					if username != cmp.Or(tt.as, "user2") {
+						t.Fatalf("storage got author %q", username)
+					}
+					if expected != tt.expected {
+						t.Fatalf("storage got expected version %d, want %d", expected, tt.expected)
+					}
+					if tt.rollbackErr != nil {
+						return internal.Bid{}, tt.rollbackErr
+					}
+					if bidId != handlertest.BidId || version != 2 {
+						t.Fatalf("storage got bid %s, version %d", bidId, version)
+					}
+
+					rolledBack := handlertest.Bid()
+					rolledBack.Version = 4
+					return rolledBack, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user2")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).WithHeader("If-Match", tt.ifMatch).Handle(http.MethodPut, "/api/bids/{bidId}/rollback/{version}", bidrollback.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPut, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			resp.WantHeader("ETag", `"4"`)
+
+			var bid internal.Bid
+			resp.Decode(&bid)
+			if bid.Version != 4 {
+				t.Fatalf("got bid %+v", bid)
+			}
+		})
+	}
+}
@@ -1,97 +1,88 @@
 package tndrollback
 
 import (
-	"errors"
+	"context"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
-	"tender-app-backend/src/internal/storage"
 )
 
 type TenderRollbacker interface {
-	RollbackTender(tenderId, version int) (internal.Tender, error)
+	authz.Facts
+	RollbackTender(ctx context.Context, tenderId uuid.UUID, version, expected int, username string) (internal.Tender, error)
 }
 
 func New(log *slog.Logger, tenderRollbacker TenderRollbacker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.rollback.tndrollback.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		tenderIdStr := chi.URLParam(r, "tenderId")
-		if tenderIdStr == "" {
-			log.Info("tender id is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		tenderId, err := uuid.Parse(chi.URLParam(r, "tenderId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		tenderId, err := strconv.Atoi(tenderIdStr)
+		version, err := strconv.Atoi(chi.URLParam(r, "version"))
 		if err != nil {
-			log.Info("failed to parse tender id")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		versionStr := chi.URLParam(r, "version")
-		if versionStr == "" {
-			log.Info("version is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		expected, err := etag.Expected(r, 0)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		version, err := strconv.Atoi(versionStr)
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
 		if err != nil {
-			log.Info("failed to parse version")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+			response.Fail(w, r, log, err)
 
 			return
 		}
 
-		tender, err := tenderRollbacker.RollbackTender(tenderId, version)
+		current, err := tenderRollbacker.GetTender(r.Context(), tenderId)
 		if err != nil {
-			if errors.Is(err, storage.ErrTenderNotFound) {
-				log.Info(
-					"tender not found",
-					slog.String("tender_id", tenderIdStr),
-					slog.String("version", versionStr),
-				)
+			response.Fail(w, r, log, response.FromStorage(err, "roll back tender"))
 
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, response.Error("tender or its version not found"))
+			return
+		}
 
-				return
-			}
+		err = authz.Check(r.Context(), log, tenderRollbacker, authz.RollbackTender, username, authz.Tender(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "roll back tender"))
 
-			log.Error("failed to roll back tender", sl.Err(err))
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to roll back tender"))
+		tender, err := tenderRollbacker.RollbackTender(r.Context(), tenderId, version, expected, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "roll back tender"))
 
 			return
 		}
 
 		log.Info("tender rolled back", slog.Any("tender", tender))
 
+		etag.Set(w, tender.Version)
+
 		render.JSON(w, r, tender)
 	}
 }
@@ -0,0 +1,87 @@
+package tndrollback_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/rollback/tndrollback"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestRollbackTender(t *testing.T) {
+	prefix := "/api/tenders/" + handlertest.TenderId.String() + "/rollback/"
+
+	tests := []struct {
+		name        string
+		as          string
+		anonymous   bool
+		target      string
+		ifMatch     string
+		expected    int
+		rollbackErr error
+		code        int
+		errMsg      string
+	}{
+		{name: "rolled back", target: prefix + "1", code: http.StatusOK},
+		{name: "invalid id", target: "/api/tenders/42/rollback/1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid version", target: prefix + "first", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "version not found", target: prefix + "1", rollbackErr: storage.ErrTenderNotFound, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "storage failure", target: prefix + "1", rollbackErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to roll back tender"},
+		{name: "expected version in If-Match", target: prefix + "1", ifMatch: `"1"`, expected: 1, code: http.StatusOK},
+		{name: "malformed If-Match", target: prefix + "1", ifMatch: "1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "version conflict", target: prefix + "1", ifMatch: `"1"`, expected: 1, rollbackErr: storage.ErrVersionConflict, code: http.StatusConflict, errMsg: "version is not the current one"},
+		{name: "anonymous", target: prefix + "1", anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "not allowed", as: "user3", target: prefix + "1", code: http.StatusForbidden, errMsg: "user is not allowed to roll back tender"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				RollbackTenderFunc: func(_ context.Context, tenderId uuid.UUID, version int, expected int, username string) (internal.Tender, error) {
+					if username != cmp.Or(tt.as, "user1") {
+						t.Fatalf("storage got author %q", username)
+					}
+					if expected != tt.expected {
+						t.Fatalf("storage got expected version %d, want %d", expected, tt.expected)
+					}
+					if tt.rollbackErr != nil {
+						return internal.Tender{}, tt.rollbackErr
+					}
+					if tenderId != handlertest.TenderId || version != 1 {
+						t.Fatalf("storage got tender %s, version %d", tenderId, version)
+					}
+
+					rolledBack := handlertest.Tender()
+					rolledBack.Version = 3
+					return rolledBack, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).WithHeader("If-Match", tt.ifMatch).Handle(http.MethodPut, "/api/tenders/{tenderId}/rollback/{version}", tndrollback.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPut, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			resp.WantHeader("ETag", `"3"`)
+
+			var tender internal.Tender
+			resp.Decode(&tender)
+			if tender.Version != 3 {
+				t.Fatalf("got tender %+v", tender)
+			}
+		})
+	}
+}
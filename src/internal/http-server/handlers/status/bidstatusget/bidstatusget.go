@@ -0,0 +1,60 @@
+package bidstatusget
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
+	"tender-app-backend/src/internal/lib/api/response"
+	"tender-app-backend/src/internal/storage"
+)
+
+type BidStatusGetter interface {
+	authz.Facts
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+}
+
+func New(log *slog.Logger, statusGetter BidStatusGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.status.bidstatusget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		bidId, err := uuid.Parse(chi.URLParam(r, "bidId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		viewer := auth.Viewer(r)
+
+		bid, err := statusGetter.GetBid(r.Context(), bidId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get bid status"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, statusGetter, authz.ViewBid, viewer.Username, authz.Bid(bid))
+		if err != nil {
+			response.Fail(w, r, log, response.FromHidden(err, storage.ErrBidNotFound, "get bid status"))
+
+			return
+		}
+
+		etag.Set(w, bid.Version)
+
+		render.JSON(w, r, bid.Status)
+	}
+}
@@ -0,0 +1,66 @@
+package bidstatusget_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/status/bidstatusget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestGetBidStatus(t *testing.T) {
+	target := "/api/bids/" + handlertest.BidId.String() + "/status?username=user2"
+
+	tests := []struct {
+		name   string
+		as     string
+		target string
+		getErr error
+		code   int
+		errMsg string
+	}{
+		{name: "status", as: "user2", target: target, code: http.StatusOK},
+		{name: "username from token", as: "user2", target: "/api/bids/" + handlertest.BidId.String() + "/status", code: http.StatusOK},
+		{name: "invalid id", as: "user2", target: "/api/bids/42/status?username=user2", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "bid not found", as: "user2", target: target, getErr: storage.ErrBidNotFound, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "hidden bid", as: "user3", target: target, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "anonymous", target: target, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "storage failure", as: "user2", target: target, getErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get bid status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetBidFunc: func(_ context.Context, bidId uuid.UUID) (internal.Bid, error) {
+					if tt.getErr != nil {
+						return internal.Bid{}, tt.getErr
+					}
+					if bidId != handlertest.BidId {
+						t.Fatalf("storage got bid %s", bidId)
+					}
+					return handlertest.Bid(), nil
+				},
+			}
+
+			srv := handlertest.New(t).As(tt.as).Handle(http.MethodGet, "/api/bids/{bidId}/status", bidstatusget.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			resp.WantHeader("ETag", `"1"`)
+
+			var status string
+			resp.Decode(&status)
+			if status != internal.BidCreated {
+				t.Fatalf("got status %q", status)
+			}
+		})
+	}
+}
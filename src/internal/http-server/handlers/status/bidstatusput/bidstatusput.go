@@ -0,0 +1,83 @@
+package bidstatusput
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type BidStatusUpdater interface {
+	authz.Facts
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	UpdateBidStatus(ctx context.Context, bidId uuid.UUID, status, username string) (internal.Bid, error)
+}
+
+func New(log *slog.Logger, statusUpdater BidStatusUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.status.bidstatusput.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		bidId, err := uuid.Parse(chi.URLParam(r, "bidId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		status := strings.ToUpper(r.URL.Query().Get("status"))
+		if status == "" {
+			response.Fail(w, r, log, response.BadRequest(errors.New("status is empty")))
+
+			return
+		}
+
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := statusUpdater.GetBid(r.Context(), bidId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "update bid status"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, statusUpdater, authz.ChangeBidStatus, username, authz.Bid(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "update bid status"))
+
+			return
+		}
+
+		bid, err := statusUpdater.UpdateBidStatus(r.Context(), bidId, status, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "update bid status"))
+
+			return
+		}
+
+		log.Info("bid status updated", slog.Any("bid", bid))
+
+		etag.Set(w, bid.Version)
+
+		render.JSON(w, r, bid)
+	}
+}
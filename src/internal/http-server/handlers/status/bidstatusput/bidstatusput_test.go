@@ -0,0 +1,80 @@
+package bidstatusput_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/status/bidstatusput"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestUpdateBidStatus(t *testing.T) {
+	prefix := "/api/bids/" + handlertest.BidId.String() + "/status"
+	target := prefix + "?status=Canceled&username=user2"
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		updateErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "updated", target: target, code: http.StatusOK},
+		{name: "invalid id", target: "/api/bids/42/status?status=Canceled&username=user2", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "no status", target: prefix + "?username=user2", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "username from token", target: prefix + "?status=Canceled", code: http.StatusOK},
+		{name: "bid not found", target: target, updateErr: storage.ErrBidNotFound, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "not author", target: target, updateErr: storage.ErrOrgRespNotFound, code: http.StatusForbidden, errMsg: "user is not allowed to update bid status"},
+		{name: "invalid transition", target: target, updateErr: storage.ErrInvalidTransition, code: http.StatusBadRequest, errMsg: "status transition not allowed"},
+		{name: "bid deadline passed", target: target, updateErr: storage.ErrBidDeadlinePassed, code: http.StatusForbidden, errMsg: "tender bid deadline has passed"},
+		{name: "storage failure", target: target, updateErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to update bid status"},
+		{name: "anonymous", target: target, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other user", target: prefix + "?status=Canceled&username=user1", code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+		{name: "not allowed", as: "user3", target: prefix + "?status=Canceled", code: http.StatusForbidden, errMsg: "user is not allowed to update bid status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				UpdateBidStatusFunc: func(_ context.Context, bidId uuid.UUID, status, username string) (internal.Bid, error) {
+					if tt.updateErr != nil {
+						return internal.Bid{}, tt.updateErr
+					}
+					if bidId != handlertest.BidId || status != internal.BidCanceled || username != "user2" {
+						t.Fatalf("storage got bid %s, status %q, username %q", bidId, status, username)
+					}
+
+					updated := handlertest.Bid()
+					updated.Status = status
+					return updated, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user2")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodPut, "/api/bids/{bidId}/status", bidstatusput.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPut, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var bid internal.Bid
+			resp.Decode(&bid)
+			if bid.Status != internal.BidCanceled {
+				t.Fatalf("got bid %+v", bid)
+			}
+		})
+	}
+}
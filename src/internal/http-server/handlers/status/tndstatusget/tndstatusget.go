@@ -0,0 +1,57 @@
+package tndstatusget
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
+	"tender-app-backend/src/internal/lib/api/response"
+	"tender-app-backend/src/internal/storage"
+)
+
+type TenderStatusGetter interface {
+	authz.Facts
+}
+
+func New(log *slog.Logger, statusGetter TenderStatusGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.status.tndstatusget.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		tenderId, err := uuid.Parse(chi.URLParam(r, "tenderId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		viewer := auth.Viewer(r)
+
+		tender, err := statusGetter.GetTender(r.Context(), tenderId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "get tender status"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, statusGetter, authz.ViewTender, viewer.Username, authz.Tender(tender))
+		if err != nil {
+			response.Fail(w, r, log, response.FromHidden(err, storage.ErrTenderNotFound, "get tender status"))
+
+			return
+		}
+
+		etag.Set(w, tender.Version)
+
+		render.JSON(w, r, tender.Status)
+	}
+}
@@ -0,0 +1,70 @@
+package tndstatusget_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/status/tndstatusget"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestGetTenderStatus(t *testing.T) {
+	target := "/api/tenders/" + handlertest.TenderId.String() + "/status?username=user1"
+
+	tests := []struct {
+		name   string
+		as     string
+		target string
+		status string
+		getErr error
+		code   int
+		errMsg string
+	}{
+		{name: "status", as: "user1", target: target, status: internal.TenderCreated, code: http.StatusOK},
+		{name: "published", target: target, status: internal.TenderPublished, code: http.StatusOK},
+		{name: "invalid id", target: "/api/tenders/42/status", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "tender not found", target: target, getErr: storage.ErrTenderNotFound, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "hidden tender", as: "user3", target: target, status: internal.TenderCreated, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "anonymous", target: target, status: internal.TenderCreated, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "storage failure", target: target, getErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to get tender status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetTenderFunc: func(_ context.Context, tenderId uuid.UUID) (internal.Tender, error) {
+					if tt.getErr != nil {
+						return internal.Tender{}, tt.getErr
+					}
+					if tenderId != handlertest.TenderId {
+						t.Fatalf("storage got tender %s", tenderId)
+					}
+
+					tender := handlertest.Tender()
+					tender.Status = tt.status
+					return tender, nil
+				},
+			}
+
+			srv := handlertest.New(t).As(tt.as).Handle(http.MethodGet, "/api/tenders/{tenderId}/status", tndstatusget.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			resp.WantHeader("ETag", `"1"`)
+
+			var status string
+			resp.Decode(&status)
+			if status != tt.status {
+				t.Fatalf("got status %q, want %q", status, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package tndstatusput
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type TenderStatusUpdater interface {
+	authz.Facts
+	UpdateTenderStatus(ctx context.Context, tenderId uuid.UUID, status, username string) (internal.Tender, error)
+}
+
+func New(log *slog.Logger, statusUpdater TenderStatusUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.status.tndstatusput.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		tenderId, err := uuid.Parse(chi.URLParam(r, "tenderId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		status := strings.ToUpper(r.URL.Query().Get("status"))
+		if status == "" {
+			response.Fail(w, r, log, response.BadRequest(errors.New("status is empty")))
+
+			return
+		}
+
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := statusUpdater.GetTender(r.Context(), tenderId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "update tender status"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, statusUpdater, authz.ChangeTenderStatus, username, authz.Tender(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "update tender status"))
+
+			return
+		}
+
+		tender, err := statusUpdater.UpdateTenderStatus(r.Context(), tenderId, status, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "update tender status"))
+
+			return
+		}
+
+		log.Info("tender status updated", slog.Any("tender", tender))
+
+		etag.Set(w, tender.Version)
+
+		render.JSON(w, r, tender)
+	}
+}
@@ -0,0 +1,80 @@
+package tndstatusput_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/status/tndstatusput"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestUpdateTenderStatus(t *testing.T) {
+	prefix := "/api/tenders/" + handlertest.TenderId.String() + "/status"
+	target := prefix + "?status=Published&username=user1"
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		updateErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "updated", target: target, code: http.StatusOK},
+		{name: "invalid id", target: "/api/tenders/42/status?status=Published&username=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "no status", target: prefix + "?username=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "username from token", target: prefix + "?status=Published", code: http.StatusOK},
+		{name: "tender not found", target: target, updateErr: storage.ErrTenderNotFound, code: http.StatusNotFound, errMsg: "tender not found"},
+		{name: "unknown user", target: target, updateErr: storage.ErrUserNotFound, code: http.StatusUnauthorized, errMsg: "user does not exist"},
+		{name: "not responsible", target: target, updateErr: storage.ErrOrgRespNotFound, code: http.StatusForbidden, errMsg: "user is not allowed to update tender status"},
+		{name: "invalid transition", target: target, updateErr: storage.ErrInvalidTransition, code: http.StatusBadRequest, errMsg: "status transition not allowed"},
+		{name: "storage failure", target: target, updateErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to update tender status"},
+		{name: "anonymous", target: target, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other user", target: prefix + "?status=Published&username=user2", code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+		{name: "not allowed", as: "user3", target: prefix + "?status=Published", code: http.StatusForbidden, errMsg: "user is not allowed to update tender status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				UpdateTenderStatusFunc: func(_ context.Context, tenderId uuid.UUID, status, username string) (internal.Tender, error) {
+					if tt.updateErr != nil {
+						return internal.Tender{}, tt.updateErr
+					}
+					if tenderId != handlertest.TenderId || status != internal.TenderPublished || username != "user1" {
+						t.Fatalf("storage got tender %s, status %q, username %q", tenderId, status, username)
+					}
+
+					updated := handlertest.Tender()
+					updated.Status = status
+					return updated, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodPut, "/api/tenders/{tenderId}/status", tndstatusput.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPut, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var tender internal.Tender
+			resp.Decode(&tender)
+			if tender.Status != internal.TenderPublished {
+				t.Fatalf("got tender %+v", tender)
+			}
+		})
+	}
+}
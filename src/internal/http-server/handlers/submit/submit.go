@@ -1,121 +1,86 @@
 package submit
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"log/slog"
 	"net/http"
-	"strconv"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/etag"
 	"tender-app-backend/src/internal/lib/api/response"
-	"tender-app-backend/src/internal/lib/logger/sl"
-	"tender-app-backend/src/internal/storage"
 )
 
 type Submitter interface {
-	SubmitBid(bidId int, orgUsername string) error
+	authz.Facts
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	SubmitBid(ctx context.Context, bidId uuid.UUID, decision, orgUsername string) (internal.Bid, error)
 }
 
 func New(log *slog.Logger, submitter Submitter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.submit.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		bidIdStr := chi.URLParam(r, "bidId")
-		if bidIdStr == "" {
-			log.Info("bid id is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		bidId, err := uuid.Parse(chi.URLParam(r, "bidId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
 
 			return
 		}
 
-		bidId, err := strconv.Atoi(bidIdStr)
-		if err != nil {
-			log.Info("failed to parse bid id")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		decision := r.URL.Query().Get("decision")
+		if !internal.IsBidDecision(decision) {
+			response.Fail(w, r, log, response.BadRequest(errors.New("invalid decision")))
 
 			return
 		}
 
-		username := r.URL.Query().Get("username")
-		if username == "" {
-			log.Info("username is empty")
-
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid request"))
+		username, err := auth.Actor(r, r.URL.Query().Get("username"))
+		if err != nil {
+			response.Fail(w, r, log, err)
 
 			return
 		}
 
-		err = submitter.SubmitBid(bidId, username)
+		current, err := submitter.GetBid(r.Context(), bidId)
 		if err != nil {
-			if errors.Is(err, storage.ErrBidNotFound) {
-				log.Info(
-					"bid not found",
-					slog.String("bid_id", bidIdStr),
-				)
-
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, response.Error("bid not found"))
-
-				return
-			}
-
-			if errors.Is(err, storage.ErrBidNotPublished) {
-				log.Info(
-					"bid not published",
-					slog.String("bid_id", bidIdStr),
-				)
-
-				render.Status(r, http.StatusForbidden)
-				render.JSON(w, r, response.Error("bid not published"))
-
-				return
-			}
-
-			if errors.Is(err, storage.ErrTenderNotPublished) {
-				log.Info(
-					"bid tender not published",
-					slog.String("bid_id", bidIdStr),
-				)
+			response.Fail(w, r, log, response.FromStorage(err, "submit bid"))
 
-				render.Status(r, http.StatusForbidden)
-				render.JSON(w, r, response.Error("bid tender not published"))
-
-				return
-			}
-
-			if errors.Is(err, storage.ErrOrgRespNotFound) {
-				log.Info(
-					"organisation responsible user not found",
-					slog.String("username", username),
-				)
-
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, response.Error("organisation responsible user not found"))
+			return
+		}
 
-				return
-			}
+		err = authz.Check(r.Context(), log, submitter, authz.DecideBid, username, authz.Bid(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "submit bid"))
 
-			log.Error("failed to submit bid", sl.Err(err))
+			return
+		}
 
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to submit bid"))
+		bid, err := submitter.SubmitBid(r.Context(), bidId, decision, username)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "submit bid"))
 
 			return
 		}
 
-		log.Info("bid submitted", slog.String("bidId", bidIdStr))
+		log.Info(
+			"bid decision submitted",
+			slog.String("decision", decision),
+			slog.Any("bid", bid),
+		)
+
+		etag.Set(w, bid.Version)
 
-		render.JSON(w, r, response.OK())
+		render.JSON(w, r, bid)
 	}
 }
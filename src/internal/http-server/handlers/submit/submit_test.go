@@ -0,0 +1,80 @@
+package submit_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/submit"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestSubmitBid(t *testing.T) {
+	prefix := "/api/bids/" + handlertest.BidId.String() + "/submit_decision"
+	target := prefix + "?decision=Approved&username=user1"
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		submitErr error
+		code      int
+		errMsg    string
+	}{
+		{name: "submitted", target: target, code: http.StatusOK},
+		{name: "invalid id", target: "/api/bids/42/submit_decision?decision=Approved&username=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "unknown decision", target: prefix + "?decision=Maybe&username=user1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "username from token", target: prefix + "?decision=Approved", code: http.StatusOK},
+		{name: "bid not found", target: target, submitErr: storage.ErrBidNotFound, code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "bid not published", target: target, submitErr: storage.ErrBidNotPublished, code: http.StatusForbidden, errMsg: "bid not published"},
+		{name: "tender not published", target: target, submitErr: storage.ErrTenderNotPublished, code: http.StatusForbidden, errMsg: "tender not published"},
+		{name: "not responsible", target: target, submitErr: storage.ErrOrgRespNotFound, code: http.StatusForbidden, errMsg: "user is not allowed to submit bid"},
+		{name: "storage failure", target: target, submitErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to submit bid"},
+		{name: "anonymous", target: target, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "other user", target: prefix + "?decision=Approved&username=user2", code: http.StatusUnauthorized, errMsg: "username does not match the authenticated user"},
+		{name: "not allowed", as: "user3", target: prefix + "?decision=Approved", code: http.StatusForbidden, errMsg: "user is not allowed to submit bid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				SubmitBidFunc: func(_ context.Context, bidId uuid.UUID, decision, orgUsername string) (internal.Bid, error) {
+					if tt.submitErr != nil {
+						return internal.Bid{}, tt.submitErr
+					}
+					if bidId != handlertest.BidId || decision != internal.DecisionApproved || orgUsername != "user1" {
+						t.Fatalf("storage got bid %s, decision %q, username %q", bidId, decision, orgUsername)
+					}
+
+					approved := handlertest.Bid()
+					approved.Status = internal.BidApproved
+					return approved, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user1")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodPut, "/api/bids/{bidId}/submit_decision", submit.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodPut, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var bid internal.Bid
+			resp.Decode(&bid)
+			if bid.Status != internal.BidApproved {
+				t.Fatalf("got bid %+v", bid)
+			}
+		})
+	}
+}
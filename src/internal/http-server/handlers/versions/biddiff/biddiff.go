@@ -0,0 +1,99 @@
+package biddiff
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+// Response lists the fields changed between two versions.
+type Response struct {
+	From    int               `json:"from"`
+	To      int               `json:"to"`
+	Changes []internal.Change `json:"changes"`
+}
+
+type BidRevisionGetter interface {
+	authz.Facts
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	GetBidRevision(ctx context.Context, bidId uuid.UUID, version int) (internal.Bid, error)
+}
+
+// New compares two versions of the bid field by field.
+func New(log *slog.Logger, revisionGetter BidRevisionGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.versions.biddiff.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		bidId, err := uuid.Parse(chi.URLParam(r, "bidId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		from, err := strconv.Atoi(chi.URLParam(r, "from"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		to, err := strconv.Atoi(chi.URLParam(r, "to"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := revisionGetter.GetBid(r.Context(), bidId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view bid versions"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, revisionGetter, authz.ViewBidVersions, username, authz.Bid(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view bid versions"))
+
+			return
+		}
+
+		older, err := revisionGetter.GetBidRevision(r.Context(), bidId, from)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view bid versions"))
+
+			return
+		}
+
+		newer, err := revisionGetter.GetBidRevision(r.Context(), bidId, to)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view bid versions"))
+
+			return
+		}
+
+		render.JSON(w, r, Response{From: from, To: to, Changes: internal.DiffBids(older, newer)})
+	}
+}
@@ -0,0 +1,86 @@
+package biddiff_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"slices"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/versions/biddiff"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestDiffBidVersions(t *testing.T) {
+	prefix := "/api/bids/" + handlertest.BidId.String() + "/versions/"
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		getErr    error
+		code      int
+		errMsg    string
+		changes   []internal.Change
+	}{
+		{name: "changed", target: prefix + "1/diff/2", code: http.StatusOK, changes: []internal.Change{{Field: "description", From: "Old", To: "New"}}},
+		{name: "same version", target: prefix + "2/diff/2", code: http.StatusOK, changes: []internal.Change{}},
+		{name: "invalid id", target: "/api/bids/42/versions/1/diff/2", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid version", target: prefix + "first/diff/2", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "version not found", target: prefix + "1/diff/9", code: http.StatusNotFound, errMsg: "version not found"},
+		{name: "bid not found", target: "/api/bids/" + uuid.NewString() + "/versions/1/diff/2", code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "storage failure", target: prefix + "1/diff/2", getErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to view bid versions"},
+		{name: "anonymous", target: prefix + "1/diff/2", anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "not allowed", as: "user3", target: prefix + "1/diff/2", code: http.StatusForbidden, errMsg: "user is not allowed to view bid versions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetBidRevisionFunc: func(_ context.Context, bidId uuid.UUID, version int) (internal.Bid, error) {
+					if tt.getErr != nil {
+						return internal.Bid{}, tt.getErr
+					}
+					if bidId != handlertest.BidId {
+						t.Fatalf("storage got bid %s", bidId)
+					}
+
+					bid := handlertest.Bid()
+					bid.Version = version
+					switch version {
+					case 1:
+						bid.Description = "Old"
+					case 2:
+						bid.Description = "New"
+					default:
+						return internal.Bid{}, storage.ErrVersionNotFound
+					}
+					return bid, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user2")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodGet, "/api/bids/{bidId}/versions/{from}/diff/{to}", biddiff.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var diff biddiff.Response
+			resp.Decode(&diff)
+			if diff.To != 2 || !slices.Equal(diff.Changes, tt.changes) {
+				t.Fatalf("got diff %+v, want changes %+v", diff, tt.changes)
+			}
+		})
+	}
+}
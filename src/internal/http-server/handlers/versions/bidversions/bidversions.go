@@ -0,0 +1,78 @@
+package bidversions
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type BidRevisionsGetter interface {
+	authz.Facts
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	GetBidRevisions(ctx context.Context, bidId uuid.UUID, page internal.Page) ([]internal.Revision, error)
+}
+
+// New lists the versions of the bid with their authors, oldest first.
+func New(log *slog.Logger, revisionsGetter BidRevisionsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.versions.bidversions.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		bidId, err := uuid.Parse(chi.URLParam(r, "bidId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		page, err := paging.Parse(r)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := revisionsGetter.GetBid(r.Context(), bidId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view bid versions"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, revisionsGetter, authz.ViewBidVersions, username, authz.Bid(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view bid versions"))
+
+			return
+		}
+
+		res, err := revisionsGetter.GetBidRevisions(r.Context(), bidId, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view bid versions"))
+
+			return
+		}
+
+		render.JSON(w, r, res)
+	}
+}
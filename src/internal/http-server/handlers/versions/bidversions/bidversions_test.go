@@ -0,0 +1,72 @@
+package bidversions_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/handlers/versions/bidversions"
+	"tender-app-backend/src/internal/http-server/handlertest"
+	"testing"
+)
+
+func TestGetBidRevisions(t *testing.T) {
+	target := "/api/bids/" + handlertest.BidId.String() + "/versions"
+
+	tests := []struct {
+		name      string
+		as        string
+		anonymous bool
+		target    string
+		page      internal.Page
+		getErr    error
+		code      int
+		errMsg    string
+	}{
+		{name: "default page", target: target, page: internal.Page{Limit: 5}, code: http.StatusOK},
+		{name: "paged", target: target + "?limit=1&offset=1", page: internal.Page{Limit: 1, Offset: 1}, code: http.StatusOK},
+		{name: "invalid id", target: "/api/bids/42/versions", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "invalid page", target: target + "?limit=-1", code: http.StatusBadRequest, errMsg: "invalid request"},
+		{name: "bid not found", target: "/api/bids/" + uuid.NewString() + "/versions", code: http.StatusNotFound, errMsg: "bid not found"},
+		{name: "storage failure", target: target, page: internal.Page{Limit: 5}, getErr: errors.New("db is down"), code: http.StatusInternalServerError, errMsg: "failed to view bid versions"},
+		{name: "anonymous", target: target, anonymous: true, code: http.StatusUnauthorized, errMsg: "authentication required"},
+		{name: "not allowed", as: "user3", target: target, code: http.StatusForbidden, errMsg: "user is not allowed to view bid versions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &handlertest.Storage{
+				GetBidRevisionsFunc: func(_ context.Context, bidId uuid.UUID, page internal.Page) ([]internal.Revision, error) {
+					if tt.getErr != nil {
+						return nil, tt.getErr
+					}
+					if bidId != handlertest.BidId || page != tt.page {
+						t.Fatalf("storage got bid %s, page %+v", bidId, page)
+					}
+					return []internal.Revision{handlertest.Revision()}, nil
+				},
+			}
+
+			as := cmp.Or(tt.as, "user2")
+			if tt.anonymous {
+				as = ""
+			}
+
+			srv := handlertest.New(t).As(as).Handle(http.MethodGet, "/api/bids/{bidId}/versions", bidversions.New(handlertest.Logger(), fake.WithFixtures()))
+
+			resp := srv.Do(http.MethodGet, tt.target, "").WantStatus(tt.code)
+			if tt.errMsg != "" {
+				resp.WantError(tt.errMsg)
+				return
+			}
+
+			var revisions []internal.Revision
+			resp.Decode(&revisions)
+			if len(revisions) != 1 || revisions[0] != handlertest.Revision() {
+				t.Fatalf("got revisions %+v", revisions)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package tnddiff
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+// Response lists the fields changed between two versions.
+type Response struct {
+	From    int               `json:"from"`
+	To      int               `json:"to"`
+	Changes []internal.Change `json:"changes"`
+}
+
+type TenderRevisionGetter interface {
+	authz.Facts
+	GetTenderRevision(ctx context.Context, tenderId uuid.UUID, version int) (internal.Tender, error)
+}
+
+// New compares two versions of the tender field by field.
+func New(log *slog.Logger, revisionGetter TenderRevisionGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.versions.tnddiff.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		tenderId, err := uuid.Parse(chi.URLParam(r, "tenderId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		from, err := strconv.Atoi(chi.URLParam(r, "from"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		to, err := strconv.Atoi(chi.URLParam(r, "to"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := revisionGetter.GetTender(r.Context(), tenderId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view tender versions"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, revisionGetter, authz.ViewTenderVersions, username, authz.Tender(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view tender versions"))
+
+			return
+		}
+
+		older, err := revisionGetter.GetTenderRevision(r.Context(), tenderId, from)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view tender versions"))
+
+			return
+		}
+
+		newer, err := revisionGetter.GetTenderRevision(r.Context(), tenderId, to)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view tender versions"))
+
+			return
+		}
+
+		render.JSON(w, r, Response{From: from, To: to, Changes: internal.DiffTenders(older, newer)})
+	}
+}
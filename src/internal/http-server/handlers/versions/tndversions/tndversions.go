@@ -0,0 +1,77 @@
+package tndversions
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/paging"
+	"tender-app-backend/src/internal/lib/api/response"
+)
+
+type TenderRevisionsGetter interface {
+	authz.Facts
+	GetTenderRevisions(ctx context.Context, tenderId uuid.UUID, page internal.Page) ([]internal.Revision, error)
+}
+
+// New lists the versions of the tender with their authors, oldest first.
+func New(log *slog.Logger, revisionsGetter TenderRevisionsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.versions.tndversions.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		tenderId, err := uuid.Parse(chi.URLParam(r, "tenderId"))
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		page, err := paging.Parse(r)
+		if err != nil {
+			response.Fail(w, r, log, response.BadRequest(err))
+
+			return
+		}
+
+		username, err := auth.Actor(r, "")
+		if err != nil {
+			response.Fail(w, r, log, err)
+
+			return
+		}
+
+		current, err := revisionsGetter.GetTender(r.Context(), tenderId)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view tender versions"))
+
+			return
+		}
+
+		err = authz.Check(r.Context(), log, revisionsGetter, authz.ViewTenderVersions, username, authz.Tender(current))
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view tender versions"))
+
+			return
+		}
+
+		res, err := revisionsGetter.GetTenderRevisions(r.Context(), tenderId, page)
+		if err != nil {
+			response.Fail(w, r, log, response.FromStorage(err, "view tender versions"))
+
+			return
+		}
+
+		render.JSON(w, r, res)
+	}
+}
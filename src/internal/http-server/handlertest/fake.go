@@ -0,0 +1,376 @@
+package handlertest
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"time"
+)
+
+// Storage is a fake storage.Storage. Each method calls the matching
+// function field; methods left unset fail with ErrUnexpectedCall.
+type Storage struct {
+	CreateEmployeeFunc                func(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error)
+	GetEmployeeFunc                   func(ctx context.Context, id uuid.UUID) (internal.Employee, error)
+	GetEmployeeByUsernameFunc         func(ctx context.Context, username string) (internal.Employee, error)
+	GetEmployeesListFunc              func(ctx context.Context, page internal.Page) ([]internal.Employee, error)
+	EditEmployeeFunc                  func(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error)
+	DeleteEmployeeFunc                func(ctx context.Context, id uuid.UUID, actor string) error
+	CreateOrganizationFunc            func(ctx context.Context, o internal.Organization, actor string, responsibles ...string) (internal.Organization, error)
+	GetOrganizationFunc               func(ctx context.Context, orgId uuid.UUID) (internal.Organization, error)
+	GetOrganizationsListFunc          func(ctx context.Context, page internal.Page) ([]internal.Organization, error)
+	EditOrganizationFunc              func(ctx context.Context, o internal.Organization, actor string) (internal.Organization, error)
+	DeleteOrganizationFunc            func(ctx context.Context, orgId uuid.UUID, actor string) error
+	GetOrganizationResponsiblesFunc   func(ctx context.Context, orgId uuid.UUID, page internal.Page) ([]internal.Employee, error)
+	AddOrganizationResponsibleFunc    func(ctx context.Context, orgId uuid.UUID, username, actor string) error
+	RemoveOrganizationResponsibleFunc func(ctx context.Context, orgId uuid.UUID, username, actor string) error
+	IsOrganizationResponsibleFunc     func(ctx context.Context, orgId uuid.UUID, username string) (bool, error)
+	CreateTenderFunc                  func(ctx context.Context, t internal.Tender) (internal.Tender, error)
+	GetTenderFunc                     func(ctx context.Context, tenderId uuid.UUID) (internal.Tender, error)
+	GetTendersListFunc                func(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error)
+	GetUserTendersListFunc            func(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error)
+	UpdateTenderStatusFunc            func(ctx context.Context, tenderId uuid.UUID, status, username string) (internal.Tender, error)
+	EditTenderFunc                    func(ctx context.Context, t internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error)
+	RollbackTenderFunc                func(ctx context.Context, tenderId uuid.UUID, version, expected int, username string) (internal.Tender, error)
+	CloseExpiredTendersFunc           func(ctx context.Context, now time.Time) ([]internal.Tender, error)
+	GetTenderRevisionsFunc            func(ctx context.Context, tenderId uuid.UUID, page internal.Page) ([]internal.Revision, error)
+	GetTenderRevisionFunc             func(ctx context.Context, tenderId uuid.UUID, version int) (internal.Tender, error)
+	CreateBidFunc                     func(ctx context.Context, b internal.Bid) (internal.Bid, error)
+	GetBidFunc                        func(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	GetUserBidsListFunc               func(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error)
+	GetTenderBidsListFunc             func(ctx context.Context, v internal.Viewer, tenderId uuid.UUID, page internal.Page) ([]internal.Bid, error)
+	GetBidsListFunc                   func(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error)
+	UpdateBidStatusFunc               func(ctx context.Context, bidId uuid.UUID, status, username string) (internal.Bid, error)
+	EditBidFunc                       func(ctx context.Context, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error)
+	RollbackBidFunc                   func(ctx context.Context, bidId uuid.UUID, version, expected int, username string) (internal.Bid, error)
+	GetBidRevisionsFunc               func(ctx context.Context, bidId uuid.UUID, page internal.Page) ([]internal.Revision, error)
+	GetBidRevisionFunc                func(ctx context.Context, bidId uuid.UUID, version int) (internal.Bid, error)
+	SubmitBidFunc                     func(ctx context.Context, bidId uuid.UUID, decision, orgUsername string) (internal.Bid, error)
+	SubmitBidFeedbackFunc             func(ctx context.Context, bidId uuid.UUID, feedback, username string) (internal.Bid, error)
+	GetBidReviewsFunc                 func(ctx context.Context, tenderId uuid.UUID, authorUsername, requesterUsername string, page internal.Page) ([]internal.Review, error)
+	GetAuditEventsFunc                func(ctx context.Context, f internal.AuditFilter, page internal.Page) ([]internal.AuditEvent, error)
+}
+
+// ErrUnexpectedCall is returned by Storage methods the test did not set up.
+var ErrUnexpectedCall = fmt.Errorf("handlertest: unexpected storage call")
+
+func unexpected(method string) error {
+	return fmt.Errorf("%w %s", ErrUnexpectedCall, method)
+}
+
+func (s *Storage) CreateEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+	if s.CreateEmployeeFunc == nil {
+		return internal.Employee{}, unexpected("CreateEmployee")
+	}
+
+	return s.CreateEmployeeFunc(ctx, e, actor)
+}
+
+func (s *Storage) GetEmployee(ctx context.Context, id uuid.UUID) (internal.Employee, error) {
+	if s.GetEmployeeFunc == nil {
+		return internal.Employee{}, unexpected("GetEmployee")
+	}
+
+	return s.GetEmployeeFunc(ctx, id)
+}
+
+func (s *Storage) GetEmployeeByUsername(ctx context.Context, username string) (internal.Employee, error) {
+	if s.GetEmployeeByUsernameFunc == nil {
+		return internal.Employee{}, unexpected("GetEmployeeByUsername")
+	}
+
+	return s.GetEmployeeByUsernameFunc(ctx, username)
+}
+
+func (s *Storage) GetEmployeesList(ctx context.Context, page internal.Page) ([]internal.Employee, error) {
+	if s.GetEmployeesListFunc == nil {
+		return nil, unexpected("GetEmployeesList")
+	}
+
+	return s.GetEmployeesListFunc(ctx, page)
+}
+
+func (s *Storage) EditEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+	if s.EditEmployeeFunc == nil {
+		return internal.Employee{}, unexpected("EditEmployee")
+	}
+
+	return s.EditEmployeeFunc(ctx, e, actor)
+}
+
+func (s *Storage) DeleteEmployee(ctx context.Context, id uuid.UUID, actor string) error {
+	if s.DeleteEmployeeFunc == nil {
+		return unexpected("DeleteEmployee")
+	}
+
+	return s.DeleteEmployeeFunc(ctx, id, actor)
+}
+
+func (s *Storage) CreateOrganization(ctx context.Context, o internal.Organization, actor string, responsibles ...string) (internal.Organization, error) {
+	if s.CreateOrganizationFunc == nil {
+		return internal.Organization{}, unexpected("CreateOrganization")
+	}
+
+	return s.CreateOrganizationFunc(ctx, o, actor, responsibles...)
+}
+
+func (s *Storage) GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error) {
+	if s.GetOrganizationFunc == nil {
+		return internal.Organization{}, unexpected("GetOrganization")
+	}
+
+	return s.GetOrganizationFunc(ctx, orgId)
+}
+
+func (s *Storage) GetOrganizationsList(ctx context.Context, page internal.Page) ([]internal.Organization, error) {
+	if s.GetOrganizationsListFunc == nil {
+		return nil, unexpected("GetOrganizationsList")
+	}
+
+	return s.GetOrganizationsListFunc(ctx, page)
+}
+
+func (s *Storage) EditOrganization(ctx context.Context, o internal.Organization, actor string) (internal.Organization, error) {
+	if s.EditOrganizationFunc == nil {
+		return internal.Organization{}, unexpected("EditOrganization")
+	}
+
+	return s.EditOrganizationFunc(ctx, o, actor)
+}
+
+func (s *Storage) DeleteOrganization(ctx context.Context, orgId uuid.UUID, actor string) error {
+	if s.DeleteOrganizationFunc == nil {
+		return unexpected("DeleteOrganization")
+	}
+
+	return s.DeleteOrganizationFunc(ctx, orgId, actor)
+}
+
+func (s *Storage) GetOrganizationResponsibles(ctx context.Context, orgId uuid.UUID, page internal.Page) ([]internal.Employee, error) {
+	if s.GetOrganizationResponsiblesFunc == nil {
+		return nil, unexpected("GetOrganizationResponsibles")
+	}
+
+	return s.GetOrganizationResponsiblesFunc(ctx, orgId, page)
+}
+
+func (s *Storage) AddOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error {
+	if s.AddOrganizationResponsibleFunc == nil {
+		return unexpected("AddOrganizationResponsible")
+	}
+
+	return s.AddOrganizationResponsibleFunc(ctx, orgId, username, actor)
+}
+
+func (s *Storage) RemoveOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error {
+	if s.RemoveOrganizationResponsibleFunc == nil {
+		return unexpected("RemoveOrganizationResponsible")
+	}
+
+	return s.RemoveOrganizationResponsibleFunc(ctx, orgId, username, actor)
+}
+
+func (s *Storage) IsOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username string) (bool, error) {
+	if s.IsOrganizationResponsibleFunc == nil {
+		return false, unexpected("IsOrganizationResponsible")
+	}
+
+	return s.IsOrganizationResponsibleFunc(ctx, orgId, username)
+}
+
+func (s *Storage) CreateTender(ctx context.Context, t internal.Tender) (internal.Tender, error) {
+	if s.CreateTenderFunc == nil {
+		return internal.Tender{}, unexpected("CreateTender")
+	}
+
+	return s.CreateTenderFunc(ctx, t)
+}
+
+func (s *Storage) GetTender(ctx context.Context, tenderId uuid.UUID) (internal.Tender, error) {
+	if s.GetTenderFunc == nil {
+		return internal.Tender{}, unexpected("GetTender")
+	}
+
+	return s.GetTenderFunc(ctx, tenderId)
+}
+
+func (s *Storage) GetTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
+	if s.GetTendersListFunc == nil {
+		return nil, unexpected("GetTendersList")
+	}
+
+	return s.GetTendersListFunc(ctx, v, serviceTypes, page)
+}
+
+func (s *Storage) GetUserTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
+	if s.GetUserTendersListFunc == nil {
+		return nil, unexpected("GetUserTendersList")
+	}
+
+	return s.GetUserTendersListFunc(ctx, v, serviceTypes, page)
+}
+
+func (s *Storage) UpdateTenderStatus(ctx context.Context, tenderId uuid.UUID, status, username string) (internal.Tender, error) {
+	if s.UpdateTenderStatusFunc == nil {
+		return internal.Tender{}, unexpected("UpdateTenderStatus")
+	}
+
+	return s.UpdateTenderStatusFunc(ctx, tenderId, status, username)
+}
+
+func (s *Storage) EditTender(ctx context.Context, t internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error) {
+	if s.EditTenderFunc == nil {
+		return internal.Tender{}, unexpected("EditTender")
+	}
+
+	return s.EditTenderFunc(ctx, t, editId, expected, username)
+}
+
+func (s *Storage) RollbackTender(ctx context.Context, tenderId uuid.UUID, version, expected int, username string) (internal.Tender, error) {
+	if s.RollbackTenderFunc == nil {
+		return internal.Tender{}, unexpected("RollbackTender")
+	}
+
+	return s.RollbackTenderFunc(ctx, tenderId, version, expected, username)
+}
+
+func (s *Storage) CloseExpiredTenders(ctx context.Context, now time.Time) ([]internal.Tender, error) {
+	if s.CloseExpiredTendersFunc == nil {
+		return nil, unexpected("CloseExpiredTenders")
+	}
+
+	return s.CloseExpiredTendersFunc(ctx, now)
+}
+
+func (s *Storage) GetTenderRevisions(ctx context.Context, tenderId uuid.UUID, page internal.Page) ([]internal.Revision, error) {
+	if s.GetTenderRevisionsFunc == nil {
+		return nil, unexpected("GetTenderRevisions")
+	}
+
+	return s.GetTenderRevisionsFunc(ctx, tenderId, page)
+}
+
+func (s *Storage) GetTenderRevision(ctx context.Context, tenderId uuid.UUID, version int) (internal.Tender, error) {
+	if s.GetTenderRevisionFunc == nil {
+		return internal.Tender{}, unexpected("GetTenderRevision")
+	}
+
+	return s.GetTenderRevisionFunc(ctx, tenderId, version)
+}
+
+func (s *Storage) CreateBid(ctx context.Context, b internal.Bid) (internal.Bid, error) {
+	if s.CreateBidFunc == nil {
+		return internal.Bid{}, unexpected("CreateBid")
+	}
+
+	return s.CreateBidFunc(ctx, b)
+}
+
+func (s *Storage) GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error) {
+	if s.GetBidFunc == nil {
+		return internal.Bid{}, unexpected("GetBid")
+	}
+
+	return s.GetBidFunc(ctx, bidId)
+}
+
+func (s *Storage) GetUserBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error) {
+	if s.GetUserBidsListFunc == nil {
+		return nil, unexpected("GetUserBidsList")
+	}
+
+	return s.GetUserBidsListFunc(ctx, v, page)
+}
+
+func (s *Storage) GetTenderBidsList(ctx context.Context, v internal.Viewer, tenderId uuid.UUID, page internal.Page) ([]internal.Bid, error) {
+	if s.GetTenderBidsListFunc == nil {
+		return nil, unexpected("GetTenderBidsList")
+	}
+
+	return s.GetTenderBidsListFunc(ctx, v, tenderId, page)
+}
+
+func (s *Storage) GetBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error) {
+	if s.GetBidsListFunc == nil {
+		return nil, unexpected("GetBidsList")
+	}
+
+	return s.GetBidsListFunc(ctx, v, page)
+}
+
+func (s *Storage) UpdateBidStatus(ctx context.Context, bidId uuid.UUID, status, username string) (internal.Bid, error) {
+	if s.UpdateBidStatusFunc == nil {
+		return internal.Bid{}, unexpected("UpdateBidStatus")
+	}
+
+	return s.UpdateBidStatusFunc(ctx, bidId, status, username)
+}
+
+func (s *Storage) EditBid(ctx context.Context, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error) {
+	if s.EditBidFunc == nil {
+		return internal.Bid{}, unexpected("EditBid")
+	}
+
+	return s.EditBidFunc(ctx, b, editId, expected, username)
+}
+
+func (s *Storage) RollbackBid(ctx context.Context, bidId uuid.UUID, version, expected int, username string) (internal.Bid, error) {
+	if s.RollbackBidFunc == nil {
+		return internal.Bid{}, unexpected("RollbackBid")
+	}
+
+	return s.RollbackBidFunc(ctx, bidId, version, expected, username)
+}
+
+func (s *Storage) GetBidRevisions(ctx context.Context, bidId uuid.UUID, page internal.Page) ([]internal.Revision, error) {
+	if s.GetBidRevisionsFunc == nil {
+		return nil, unexpected("GetBidRevisions")
+	}
+
+	return s.GetBidRevisionsFunc(ctx, bidId, page)
+}
+
+func (s *Storage) GetBidRevision(ctx context.Context, bidId uuid.UUID, version int) (internal.Bid, error) {
+	if s.GetBidRevisionFunc == nil {
+		return internal.Bid{}, unexpected("GetBidRevision")
+	}
+
+	return s.GetBidRevisionFunc(ctx, bidId, version)
+}
+
+func (s *Storage) SubmitBid(ctx context.Context, bidId uuid.UUID, decision, orgUsername string) (internal.Bid, error) {
+	if s.SubmitBidFunc == nil {
+		return internal.Bid{}, unexpected("SubmitBid")
+	}
+
+	return s.SubmitBidFunc(ctx, bidId, decision, orgUsername)
+}
+
+func (s *Storage) SubmitBidFeedback(ctx context.Context, bidId uuid.UUID, feedback, username string) (internal.Bid, error) {
+	if s.SubmitBidFeedbackFunc == nil {
+		return internal.Bid{}, unexpected("SubmitBidFeedback")
+	}
+
+	return s.SubmitBidFeedbackFunc(ctx, bidId, feedback, username)
+}
+
+func (s *Storage) GetBidReviews(ctx context.Context, tenderId uuid.UUID, authorUsername, requesterUsername string, page internal.Page) ([]internal.Review, error) {
+	if s.GetBidReviewsFunc == nil {
+		return nil, unexpected("GetBidReviews")
+	}
+
+	return s.GetBidReviewsFunc(ctx, tenderId, authorUsername, requesterUsername, page)
+}
+
+func (s *Storage) GetAuditEvents(ctx context.Context, f internal.AuditFilter, page internal.Page) ([]internal.AuditEvent, error) {
+	if s.GetAuditEventsFunc == nil {
+		return nil, unexpected("GetAuditEvents")
+	}
+
+	return s.GetAuditEventsFunc(ctx, f, page)
+}
+
+func (s *Storage) Close() error {
+	return nil
+}
@@ -0,0 +1,158 @@
+package handlertest
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"slices"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"time"
+)
+
+// Identifiers shared by the fixtures.
+var (
+	TenderId       = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	BidId          = uuid.MustParse("61a485f0-e29b-41d4-a716-446655440000")
+	OrganizationId = uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	// EmployeeId is the id As gives user1.
+	EmployeeId = uuid.NewSHA1(uuid.NameSpaceOID, []byte("user1"))
+)
+
+// Responsibles of OrganizationId, the organization of both Tender and Bid.
+// Employees with other usernames are responsible for nothing.
+var Responsibles = []string{"user1", "user2"}
+
+// WithFixtures makes s answer the authorization questions about the
+// fixtures: GetTender, GetBid, GetOrganization and GetEmployee find Tender,
+// Bid, Organization and Employee, IsOrganizationResponsible knows
+// Responsibles. Functions the test already set are kept.
+func (s *Storage) WithFixtures() *Storage {
+	if s.GetTenderFunc == nil {
+		s.GetTenderFunc = func(_ context.Context, tenderId uuid.UUID) (internal.Tender, error) {
+			if tenderId != TenderId {
+				return internal.Tender{}, storage.ErrTenderNotFound
+			}
+			return Tender(), nil
+		}
+	}
+	if s.GetBidFunc == nil {
+		s.GetBidFunc = func(_ context.Context, bidId uuid.UUID) (internal.Bid, error) {
+			if bidId != BidId {
+				return internal.Bid{}, storage.ErrBidNotFound
+			}
+			return Bid(), nil
+		}
+	}
+	if s.GetOrganizationFunc == nil {
+		s.GetOrganizationFunc = func(_ context.Context, orgId uuid.UUID) (internal.Organization, error) {
+			if orgId != OrganizationId {
+				return internal.Organization{}, storage.ErrOrganizationNotFound
+			}
+			return Organization(), nil
+		}
+	}
+	if s.GetEmployeeFunc == nil {
+		s.GetEmployeeFunc = func(_ context.Context, id uuid.UUID) (internal.Employee, error) {
+			if id != EmployeeId {
+				return internal.Employee{}, storage.ErrUserNotFound
+			}
+			return Employee(), nil
+		}
+	}
+	if s.IsOrganizationResponsibleFunc == nil {
+		s.IsOrganizationResponsibleFunc = func(_ context.Context, orgId uuid.UUID, username string) (bool, error) {
+			return orgId == OrganizationId && slices.Contains(Responsibles, username), nil
+		}
+	}
+
+	return s
+}
+
+// Tender returns a tender that is valid according to the spec.
+func Tender() internal.Tender {
+	return internal.Tender{
+		Id:              TenderId,
+		Name:            "Delivery Kazan - Moscow",
+		Description:     "Deliver robotics olympiad equipment",
+		ServiceType:     "Delivery",
+		Status:          internal.TenderCreated,
+		OrganizationId:  OrganizationId,
+		CreatorUsername: "user1",
+		Version:         1,
+		CreatedAt:       time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:       time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC),
+		LastEditedBy:    "user1",
+	}
+}
+
+// Bid returns a bid on Tender that is valid according to the spec.
+func Bid() internal.Bid {
+	return internal.Bid{
+		Id:              BidId,
+		Name:            "Delivery by truck",
+		Description:     "Two days door to door",
+		Status:          internal.BidCreated,
+		TenderId:        TenderId,
+		OrganizationId:  OrganizationId,
+		CreatorUsername: "user2",
+		Version:         1,
+		CreatedAt:       time.Date(2024, 9, 2, 9, 30, 0, 0, time.UTC),
+		UpdatedAt:       time.Date(2024, 9, 2, 9, 30, 0, 0, time.UTC),
+		LastEditedBy:    "user2",
+	}
+}
+
+// Organization returns the organization of Tender and Bid.
+func Organization() internal.Organization {
+	return internal.Organization{
+		Id:          OrganizationId,
+		Name:        "Robotics olympiad",
+		Description: "Organizes robotics competitions",
+		Type:        "LLC",
+	}
+}
+
+// Employee returns user1, one of the Responsibles.
+func Employee() internal.Employee {
+	return internal.Employee{
+		Id:        EmployeeId,
+		Username:  "user1",
+		FirstName: "Ivan",
+		LastName:  "Petrov",
+	}
+}
+
+// Review returns a review that is valid according to the spec.
+func Review() internal.Review {
+	return internal.Review{
+		Id:          1,
+		Description: "All good",
+		CreatedAt:   time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// Revision returns the first version of Tender or Bid, made by user1.
+func Revision() internal.Revision {
+	return internal.Revision{
+		Version:   1,
+		Author:    "user1",
+		CreatedAt: time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// AuditEvent returns user1 publishing Tender.
+func AuditEvent() internal.AuditEvent {
+	return internal.AuditEvent{
+		Id:             1,
+		Actor:          "user1",
+		Action:         internal.ActionPublish,
+		EntityType:     internal.EntityTender,
+		EntityId:       TenderId,
+		OrganizationId: OrganizationId,
+		Before:         json.RawMessage(`{"status":"CREATED"}`),
+		After:          json.RawMessage(`{"status":"PUBLISHED"}`),
+		RequestId:      "host/abc-000001",
+		CreatedAt:      time.Date(2024, 9, 1, 12, 30, 0, 0, time.UTC),
+	}
+}
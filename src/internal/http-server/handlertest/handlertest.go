@@ -0,0 +1,153 @@
+// Package handlertest runs http handlers behind a chi router and checks
+// every request and response against the API specification.
+package handlertest
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+	"testing"
+)
+
+// Server is a router with the handlers under test mounted the same way
+// main mounts them.
+type Server struct {
+	t         *testing.T
+	router    chi.Router
+	principal *internal.Employee
+	header    http.Header
+}
+
+func New(t *testing.T) *Server {
+	t.Helper()
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(middleware.URLFormat)
+
+	return &Server{t: t, router: router, header: http.Header{}}
+}
+
+// Logger discards everything handlers log.
+func Logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func (s *Server) Handle(method, pattern string, h http.HandlerFunc) *Server {
+	s.router.Method(method, pattern, h)
+
+	return s
+}
+
+// As authenticates the requests as the employee with the given username,
+// the way middleware/auth does for a valid token. An empty username
+// leaves the requests anonymous.
+func (s *Server) As(username string) *Server {
+	s.principal = nil
+	if username != "" {
+		s.principal = &internal.Employee{Id: uuid.NewSHA1(uuid.NameSpaceOID, []byte(username)), Username: username}
+	}
+
+	return s
+}
+
+// WithHeader sets a header on the requests, an empty value removes it.
+func (s *Server) WithHeader(key, value string) *Server {
+	s.header.Del(key)
+	if value != "" {
+		s.header.Set(key, value)
+	}
+
+	return s
+}
+
+// Do serves the request and validates both the request and the response
+// against the spec. An empty body sends no request body.
+func (s *Server) Do(method, target, body string) *Response {
+	s.t.Helper()
+
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+
+	req := httptest.NewRequest(method, target, r)
+	for key := range s.header {
+		req.Header.Set(key, s.header.Get(key))
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.principal != nil {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), *s.principal))
+	}
+
+	reqErr := validateRequest(s.t, req)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+
+	checkSpec(s.t, req, reqErr, rec)
+
+	return &Response{t: s.t, Code: rec.Code, Header: rec.Header(), Body: rec.Body.Bytes()}
+}
+
+type Response struct {
+	t *testing.T
+
+	Code   int
+	Header http.Header
+	Body   []byte
+}
+
+func (r *Response) WantStatus(code int) *Response {
+	r.t.Helper()
+
+	if r.Code != code {
+		r.t.Fatalf("got status %d, want %d, body: %s", r.Code, code, r.Body)
+	}
+
+	return r
+}
+
+func (r *Response) WantHeader(key, value string) *Response {
+	r.t.Helper()
+
+	if got := r.Header.Get(key); got != value {
+		r.t.Fatalf("got %s header %q, want %q", key, got, value)
+	}
+
+	return r
+}
+
+// Decode unmarshals the JSON body into v.
+func (r *Response) Decode(v any) {
+	r.t.Helper()
+
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		r.t.Fatalf("decode body %s: %v", r.Body, err)
+	}
+}
+
+// WantError checks that the body is an error response with the given reason.
+func (r *Response) WantError(reason string) *Response {
+	r.t.Helper()
+
+	var resp response.Response
+	r.Decode(&resp)
+
+	if resp.Reason != reason {
+		r.t.Fatalf("got error response %s, want %q", r.Body, reason)
+	}
+
+	return r
+}
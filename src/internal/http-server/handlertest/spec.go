@@ -0,0 +1,50 @@
+package handlertest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"tender-app-backend/src/internal/http-server/openapi"
+	"testing"
+)
+
+var loadSpec = sync.OnceValues(openapi.Load)
+
+// validateRequest checks req against the spec the way middleware/validate
+// does in front of the handlers. Endpoints the spec does not describe are
+// not checked.
+func validateRequest(t *testing.T, req *http.Request) error {
+	t.Helper()
+
+	return mustLoadSpec(t).ValidateRequest(req)
+}
+
+// checkSpec fails the test when the response does not match the operation
+// in the spec, or when the handler accepted a request middleware/validate
+// would have refused with 400 before it got there. Endpoints the spec does
+// not describe are not checked.
+func checkSpec(t *testing.T, req *http.Request, reqErr error, rec *httptest.ResponseRecorder) {
+	t.Helper()
+
+	if reqErr != nil && rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s %s: handler answered %d to a request the spec refuses: %v",
+			req.Method, req.URL, rec.Code, reqErr)
+	}
+
+	err := mustLoadSpec(t).ValidateResponse(req, rec.Code, rec.Header(), rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("%s %s: response %d %s does not match openapi spec: %v",
+			req.Method, req.URL.Path, rec.Code, rec.Body.Bytes(), err)
+	}
+}
+
+func mustLoadSpec(t *testing.T) *openapi.Spec {
+	t.Helper()
+
+	spec, err := loadSpec()
+	if err != nil {
+		t.Fatalf("load openapi spec: %v", err)
+	}
+
+	return spec
+}
@@ -0,0 +1,110 @@
+// Package auth authenticates requests by bearer token and tells handlers
+// which employee a request acts as.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/lib/api/response"
+	"tender-app-backend/src/internal/lib/token"
+)
+
+type EmployeeGetter interface {
+	GetEmployee(ctx context.Context, id uuid.UUID) (internal.Employee, error)
+}
+
+type principalKey struct{}
+
+// New resolves the bearer token of a request to an employee and puts it
+// in the request context. Requests without a token pass anonymously,
+// a token that does not verify is answered with 401.
+func New(log *slog.Logger, tokens *token.Tokens, employees EmployeeGetter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			const op = "middleware.auth.New"
+
+			log := log.With(
+				slog.String("op", op),
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+			)
+
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			raw, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok {
+				response.Fail(w, r, log, unauthorized("invalid authorization header", nil))
+
+				return
+			}
+
+			employeeId, err := tokens.Verify(raw)
+			if err != nil {
+				response.Fail(w, r, log, unauthorized("invalid token", err))
+
+				return
+			}
+
+			e, err := employees.GetEmployee(r.Context(), employeeId)
+			if err != nil {
+				response.Fail(w, r, log, response.FromStorage(err, "authenticate"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), e)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func unauthorized(reason string, err error) *response.Error {
+	return &response.Error{Code: http.StatusUnauthorized, Reason: reason, Err: err}
+}
+
+// WithPrincipal returns ctx carrying the authenticated employee.
+func WithPrincipal(ctx context.Context, e internal.Employee) context.Context {
+	return context.WithValue(ctx, principalKey{}, e)
+}
+
+// Principal returns the authenticated employee, if any.
+func Principal(ctx context.Context) (internal.Employee, bool) {
+	e, ok := ctx.Value(principalKey{}).(internal.Employee)
+
+	return e, ok
+}
+
+// Actor returns the username r acts as. claimed is the username the client
+// put in the request, if any; it has to be the authenticated one.
+func Actor(r *http.Request, claimed string) (string, error) {
+	e, ok := Principal(r.Context())
+	if !ok {
+		return "", unauthorized("authentication required", nil)
+	}
+
+	if claimed != "" && claimed != e.Username {
+		return "", unauthorized("username does not match the authenticated user",
+			fmt.Errorf("claimed %s, authenticated as %s", claimed, e.Username))
+	}
+
+	return e.Username, nil
+}
+
+// Viewer returns who is looking at the data: the authenticated employee
+// or an anonymous viewer who only sees published data.
+func Viewer(r *http.Request) internal.Viewer {
+	e, _ := Principal(r.Context())
+
+	return internal.Viewer{Username: e.Username}
+}
@@ -0,0 +1,127 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/api/response"
+	"tender-app-backend/src/internal/lib/token"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+	"time"
+)
+
+type employees map[uuid.UUID]internal.Employee
+
+func (e employees) GetEmployee(_ context.Context, id uuid.UUID) (internal.Employee, error) {
+	employee, ok := e[id]
+	if !ok {
+		return internal.Employee{}, storage.ErrUserNotFound
+	}
+
+	return employee, nil
+}
+
+func TestAuth(t *testing.T) {
+	alice := internal.Employee{Id: uuid.New(), Username: "alice"}
+	tokens := token.New("secret", time.Hour)
+
+	issue := func(id uuid.UUID) string {
+		raw, err := tokens.Issue(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		code   int
+		reason string
+		actor  string
+	}{
+		{name: "anonymous", code: http.StatusOK},
+		{name: "valid token", header: "Bearer " + issue(alice.Id), code: http.StatusOK, actor: "alice"},
+		{name: "not bearer", header: "Basic YWxpY2U6c2VjcmV0", code: http.StatusUnauthorized, reason: "invalid authorization header"},
+		{name: "invalid token", header: "Bearer nonsense", code: http.StatusUnauthorized, reason: "invalid token"},
+		{name: "unknown employee", header: "Bearer " + issue(uuid.New()), code: http.StatusUnauthorized, reason: "user does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor string
+			router := chi.NewRouter()
+			router.Use(auth.New(slog.New(slog.NewTextHandler(io.Discard, nil)), tokens, employees{alice.Id: alice}))
+			router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				actor = auth.Viewer(r).Username
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.code {
+				t.Fatalf("got status %d, want %d, body: %s", rec.Code, tt.code, rec.Body)
+			}
+			if actor != tt.actor {
+				t.Fatalf("handler saw %q, want %q", actor, tt.actor)
+			}
+			if tt.reason == "" {
+				return
+			}
+
+			var resp response.Response
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Reason != tt.reason {
+				t.Fatalf("got reason %q, want %q", resp.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestActor(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), internal.Employee{Username: "alice"})
+
+	tests := []struct {
+		name    string
+		r       *http.Request
+		claimed string
+		want    string
+		reason  string
+	}{
+		{name: "from token", r: httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx), want: "alice"},
+		{name: "claimed matches", r: httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx), claimed: "alice", want: "alice"},
+		{name: "claimed differs", r: httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx), claimed: "bob", reason: "username does not match the authenticated user"},
+		{name: "anonymous", r: httptest.NewRequest(http.MethodGet, "/", nil), claimed: "alice", reason: "authentication required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := auth.Actor(tt.r, tt.claimed)
+			if tt.reason == "" {
+				if err != nil || got != tt.want {
+					t.Fatalf("got %q, %v, want %q", got, err, tt.want)
+				}
+				return
+			}
+
+			apiErr, ok := err.(*response.Error)
+			if !ok || apiErr.Code != http.StatusUnauthorized || apiErr.Reason != tt.reason {
+				t.Fatalf("got %v, want 401 %q", err, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Package timeout bounds how long a request may keep its context alive.
+package timeout
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// New cancels the request context after d, so storage calls still running
+// for a slow request are aborted. It writes nothing itself: the handler
+// answers with the error the cancelled call returns.
+func New(d time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,41 @@
+package timeout_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"tender-app-backend/src/internal/http-server/middleware/timeout"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	var got error
+	handler := timeout.New(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		got = r.Context().Err()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+
+	if !errors.Is(got, context.DeadlineExceeded) {
+		t.Fatalf("request context error = %v, want %v", got, context.DeadlineExceeded)
+	}
+}
+
+func TestNewKeepsParentCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var got error
+	handler := timeout.New(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Context().Err()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/ping", nil).WithContext(ctx))
+
+	if !errors.Is(got, context.Canceled) {
+		t.Fatalf("request context error = %v, want %v", got, context.Canceled)
+	}
+}
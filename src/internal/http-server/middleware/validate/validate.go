@@ -0,0 +1,76 @@
+// Package validate rejects requests that do not match the API specification.
+package validate
+
+import (
+	"bytes"
+	"github.com/go-chi/chi/v5/middleware"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal/http-server/openapi"
+	"tender-app-backend/src/internal/lib/api/response"
+	"tender-app-backend/src/internal/lib/logger/sl"
+)
+
+// New validates every request against spec and answers 400 to the ones
+// that do not match. With checkResponses it also checks what handlers
+// write and logs mismatches, the response itself is sent unchanged.
+func New(log *slog.Logger, spec *openapi.Spec, checkResponses bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			const op = "middleware.validate.New"
+
+			log := log.With(
+				slog.String("op", op),
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+			)
+
+			if err := spec.ValidateRequest(r); err != nil {
+				response.Fail(w, r, log, &response.Error{
+					Code:   http.StatusBadRequest,
+					Reason: "invalid request: " + err.Error(),
+					Err:    err,
+				})
+
+				return
+			}
+
+			if !checkResponses {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if err := spec.ValidateResponse(r, rec.status, w.Header(), rec.body.Bytes()); err != nil {
+				log.Error(
+					"response does not match api spec",
+					slog.Int("status", rec.status),
+					sl.Err(err),
+				)
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// recorder passes the response through and keeps a copy of it.
+type recorder struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
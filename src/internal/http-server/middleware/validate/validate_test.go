@@ -0,0 +1,104 @@
+package validate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tender-app-backend/src/internal/http-server/middleware/validate"
+	"tender-app-backend/src/internal/http-server/openapi"
+	"tender-app-backend/src/internal/lib/api/response"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		code   int
+		reason string
+	}{
+		{name: "valid", target: "/api/tenders/1/edit?username=user1", body: `{"name": "Roads"}`, code: http.StatusOK},
+		{name: "no username", target: "/api/tenders/1/edit", body: `{"name": "Roads"}`, code: http.StatusOK},
+		{
+			name:   "name too long",
+			target: "/api/tenders/1/edit?username=user1",
+			body:   `{"name": "` + strings.Repeat("a", 101) + `"}`,
+			code:   http.StatusBadRequest,
+			reason: `invalid request: request body has an error: doesn't match schema: field "name": maximum string length is 100`,
+		},
+		{name: "not in spec", target: "/api/tenders/1/archive", body: `{"title": "Roads"}`, code: http.StatusOK},
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			router := chi.NewRouter()
+			router.Use(validate.New(slog.New(slog.NewTextHandler(io.Discard, nil)), spec, false))
+			router.Patch("/api/*", func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				got = string(b)
+			})
+
+			req := httptest.NewRequest(http.MethodPatch, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.code {
+				t.Fatalf("got status %d, want %d, body: %s", rec.Code, tt.code, rec.Body)
+			}
+
+			if tt.reason == "" {
+				if got != tt.body {
+					t.Fatalf("handler got body %q, want %q", got, tt.body)
+				}
+				return
+			}
+
+			var resp response.Response
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Reason != tt.reason {
+				t.Fatalf("got reason %q, want %q", resp.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestValidateResponses(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	router := chi.NewRouter()
+	router.Use(validate.New(slog.New(slog.NewTextHandler(&logs, nil)), spec, true))
+	router.Get("/api/tenders/{tenderId}/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`"Open"`))
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/tenders/1/status", nil))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != `"Open"` {
+		t.Fatalf("response changed: %d %s", rec.Code, rec.Body)
+	}
+	if !strings.Contains(logs.String(), "response does not match api spec") {
+		t.Fatalf("mismatch not logged, logs: %s", logs.String())
+	}
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"slices"
+	"strings"
+)
+
+// compat adjusts the spec where the service knowingly differs from it,
+// so everything else is still checked. Drop an entry once the service
+// catches up.
+func compat(doc *openapi3.T) {
+	schemas := doc.Components.Schemas
+
+	// Statuses are returned upper-cased and accepted in either case,
+	// bids also report their outcome.
+	withUpperCase(schemas["tenderStatus"].Value)
+	withUpperCase(schemas["bidStatus"].Value, "APPROVED", "REJECTED")
+
+	// Bid authorship is not tracked yet.
+	dropRequired(schemas["bid"].Value, "authorType", "authorId")
+
+	// Review ids are serial numbers.
+	schemas["bidReviewId"].Value.Type = &openapi3.Types{openapi3.TypeInteger}
+
+	// Bids are authored by a user on behalf of an organization.
+	createBid := requestSchema(doc, "/bids/new", "POST")
+	createBid.Properties["organizationId"] = schemas["organizationId"]
+	createBid.Properties["creatorUsername"] = schemas["username"]
+	dropRequired(createBid, "authorType", "authorId")
+	createBid.Required = append(createBid.Required, "organizationId")
+
+	// The actor comes from the bearer token, naming it is optional and
+	// only checked against the token.
+	dropRequired(requestSchema(doc, "/tenders/new", "POST"), "creatorUsername")
+	for _, path := range doc.Paths.Map() {
+		for _, op := range path.Operations() {
+			for _, p := range op.Parameters {
+				if p.Value.In == openapi3.ParameterInQuery && (p.Value.Name == "username" || p.Value.Name == "requesterUsername") {
+					p.Value.Required = false
+				}
+			}
+		}
+	}
+
+	// Edits may name the version they are based on and are refused with
+	// 409 when it is not the current one, rollbacks take it in If-Match.
+	requestSchema(doc, "/tenders/{tenderId}/edit", "PATCH").Properties["version"] = schemas["tenderVersion"]
+	requestSchema(doc, "/bids/{bidId}/edit", "PATCH").Properties["version"] = schemas["bidVersion"]
+	for _, op := range []*openapi3.Operation{
+		doc.Paths.Value("/tenders/{tenderId}/edit").Patch,
+		doc.Paths.Value("/tenders/{tenderId}/rollback/{version}").Put,
+		doc.Paths.Value("/bids/{bidId}/edit").Patch,
+		doc.Paths.Value("/bids/{bidId}/rollback/{version}").Put,
+	} {
+		op.Responses.Set("409", &openapi3.ResponseRef{Value: openapi3.NewResponse().
+			WithDescription("Версия изменилась после того, как клиент её прочитал.").
+			WithJSONSchemaRef(schemas["errorResponse"])})
+	}
+
+	// Tenders may stop taking bids at a deadline.
+	bidDeadline := openapi3.NewSchemaRef("", openapi3.NewDateTimeSchema())
+	schemas["tender"].Value.Properties["bidDeadline"] = bidDeadline
+	requestSchema(doc, "/tenders/new", "POST").Properties["bidDeadline"] = bidDeadline
+	requestSchema(doc, "/tenders/{tenderId}/edit", "PATCH").Properties["bidDeadline"] = bidDeadline
+
+	// Request bodies list every field a handler reads, anything else
+	// is a client mistake.
+	for _, path := range doc.Paths.Map() {
+		for _, op := range path.Operations() {
+			if op.RequestBody == nil {
+				continue
+			}
+			for _, media := range op.RequestBody.Value.Content {
+				media.Schema.Value.AdditionalProperties = openapi3.AdditionalProperties{Has: new(bool)}
+			}
+		}
+	}
+}
+
+func withUpperCase(s *openapi3.Schema, extra ...string) {
+	for _, v := range s.Enum {
+		s.Enum = append(s.Enum, strings.ToUpper(v.(string)))
+	}
+	for _, v := range extra {
+		s.Enum = append(s.Enum, v)
+	}
+}
+
+func dropRequired(s *openapi3.Schema, fields ...string) {
+	s.Required = slices.DeleteFunc(s.Required, func(f string) bool {
+		return slices.Contains(fields, f)
+	})
+}
+
+func requestSchema(doc *openapi3.T, path, method string) *openapi3.Schema {
+	return doc.Paths.Value(path).GetOperation(method).RequestBody.Value.Content.Get("application/json").Schema.Value
+}
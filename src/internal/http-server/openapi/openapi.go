@@ -0,0 +1,148 @@
+// Package openapi embeds the API specification and checks requests
+// and responses against it.
+package openapi
+
+//go:generate cp ../../../../задание/openapi.yml openapi.yml
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Document is a copy of задание/openapi.yml, refresh it with go generate.
+//
+//go:embed openapi.yml
+var Document []byte
+
+// Spec is the loaded API specification.
+type Spec struct {
+	doc    *openapi3.T
+	router routers.Router
+}
+
+// Load parses the embedded document and adjusts it to the service, see compat.go.
+func Load() (*Spec, error) {
+	const op = "openapi.Load"
+
+	doc, err := openapi3.NewLoader().LoadFromData(Document)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Requests reach the service under any host, match them by path only.
+	for _, server := range doc.Servers {
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		server.URL = u.Path
+	}
+
+	compat(doc)
+
+	if err := doc.Validate(context.Background(), openapi3.DisableExamplesValidation()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Spec{doc: doc, router: router}, nil
+}
+
+func options() *openapi3filter.Options {
+	opts := &openapi3filter.Options{
+		IncludeResponseStatus: true,
+		// Defaults are applied by the handlers, the request is left as sent.
+		SkipSettingDefaults: true,
+	}
+	opts.WithCustomSchemaErrorFunc(schemaError)
+
+	return opts
+}
+
+// schemaError names the offending field instead of dumping the schema.
+func schemaError(err *openapi3.SchemaError) string {
+	if path := err.JSONPointer(); len(path) > 0 {
+		return fmt.Sprintf("field %q: %s", strings.Join(path, "."), err.Reason)
+	}
+
+	return err.Reason
+}
+
+// ValidateRequest checks path, query and body of r. The body is read
+// and put back, so handlers can still decode it. Endpoints the spec
+// does not describe are not checked.
+func (s *Spec) ValidateRequest(r *http.Request) error {
+	input, err := s.input(r)
+	if input == nil {
+		return err
+	}
+
+	return openapi3filter.ValidateRequest(r.Context(), input)
+}
+
+// ValidateResponse checks a response written for r. Any operation may
+// answer 400 for a malformed request or 500 for a storage failure, such
+// answers only have to be error responses.
+func (s *Spec) ValidateResponse(r *http.Request, status int, header http.Header, body []byte) error {
+	input, err := s.input(r)
+	if input == nil {
+		return err
+	}
+
+	if input.Route.Operation.Responses.Status(status) == nil &&
+		(status == http.StatusBadRequest || status == http.StatusInternalServerError) {
+		return s.validateError(body)
+	}
+
+	return openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 status,
+		Header:                 header,
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options:                input.Options,
+	})
+}
+
+func (s *Spec) validateError(body []byte) error {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Errorf("error response is not json: %w", err)
+	}
+
+	return s.doc.Components.Schemas["errorResponse"].Value.VisitJSON(v)
+}
+
+// input finds the operation for r. It returns nil and no error
+// for endpoints the spec does not describe.
+func (s *Spec) input(r *http.Request) (*openapi3filter.RequestValidationInput, error) {
+	route, pathParams, err := s.router.FindRoute(r)
+	if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options(),
+	}, nil
+}
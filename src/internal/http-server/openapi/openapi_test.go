@@ -0,0 +1,126 @@
+package openapi_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"tender-app-backend/src/internal/http-server/openapi"
+	"testing"
+)
+
+func TestDocumentUpToDate(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "задание", "openapi.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(openapi.Document, want) {
+		t.Fatal("embedded openapi.yml is stale, run go generate ./src/internal/http-server/openapi")
+	}
+}
+
+const tender = `{
+	"id": "550e8400-e29b-41d4-a716-446655440000",
+	"name": "Delivery",
+	"description": "Deliver equipment",
+	"serviceType": "Delivery",
+	"status": "%s",
+	"organizationId": "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+	"version": 1,
+	"createdAt": "2024-09-01T12:00:00Z"
+}`
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		body    string
+		wantErr bool
+	}{
+		{name: "list", method: http.MethodGet, target: "/api/tenders?limit=50&service_type=Delivery"},
+		{name: "limit too big", method: http.MethodGet, target: "/api/tenders?limit=51", wantErr: true},
+		{name: "unknown service type", method: http.MethodGet, target: "/api/tenders?service_type=Cleaning", wantErr: true},
+		{name: "status in upper case", method: http.MethodPut, target: "/api/tenders/1/status?status=PUBLISHED&username=user1"},
+		{name: "unknown status", method: http.MethodPut, target: "/api/tenders/1/status?status=Open&username=user1", wantErr: true},
+		{name: "no username", method: http.MethodPut, target: "/api/tenders/1/status?status=Published"},
+		{name: "edit", method: http.MethodPatch, target: "/api/tenders/1/edit?username=user1", body: `{"name": "Roads"}`},
+		{name: "edit with version", method: http.MethodPatch, target: "/api/tenders/1/edit?username=user1", body: `{"name": "Roads", "version": 2}`},
+		{name: "edit with deadline", method: http.MethodPatch, target: "/api/tenders/1/edit?username=user1", body: `{"bidDeadline": "2024-09-01T12:00:00Z"}`},
+		{name: "deadline not a time", method: http.MethodPatch, target: "/api/tenders/1/edit?username=user1", body: `{"bidDeadline": "tomorrow"}`, wantErr: true},
+		{name: "unknown field", method: http.MethodPatch, target: "/api/tenders/1/edit?username=user1", body: `{"title": "Roads"}`, wantErr: true},
+		{
+			name:   "bid",
+			method: http.MethodPost,
+			target: "/api/bids/new",
+			body: `{"name": "Bid", "description": "Cheap", "tenderId": "1",
+				"organizationId": "2", "creatorUsername": "user2"}`,
+		},
+		{name: "bid without creator", method: http.MethodPost, target: "/api/bids/new", body: `{"name": "Bid", "description": "Cheap", "tenderId": "1", "organizationId": "2"}`},
+		{name: "bid without organization", method: http.MethodPost, target: "/api/bids/new", body: `{"name": "Bid", "description": "Cheap", "tenderId": "1"}`, wantErr: true},
+		{name: "not in spec", method: http.MethodGet, target: "/api/tenders/status?limit=1000"},
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			err := spec.ValidateRequest(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{name: "tender", method: http.MethodPatch, target: "/api/tenders/1/edit", status: http.StatusOK, body: fmt.Sprintf(tender, "Created")},
+		{name: "upper case status", method: http.MethodPatch, target: "/api/tenders/1/edit", status: http.StatusOK, body: fmt.Sprintf(tender, "CREATED")},
+		{name: "unknown status", method: http.MethodPatch, target: "/api/tenders/1/edit", status: http.StatusOK, body: fmt.Sprintf(tender, "Open"), wantErr: true},
+		{name: "missing createdAt", method: http.MethodPatch, target: "/api/tenders/1/edit", status: http.StatusOK, body: strings.Replace(fmt.Sprintf(tender, "Created"), `"createdAt"`, `"updatedAt"`, 1), wantErr: true},
+		{name: "missing field", method: http.MethodPatch, target: "/api/tenders/1/edit", status: http.StatusOK, body: `{"id": "1"}`, wantErr: true},
+		{name: "reason", method: http.MethodPatch, target: "/api/tenders/1/edit", status: http.StatusNotFound, body: `{"reason": "tender not found"}`},
+		{name: "version conflict", method: http.MethodPatch, target: "/api/tenders/1/edit", status: http.StatusConflict, body: `{"reason": "version is not the current one"}`},
+		{name: "error envelope", method: http.MethodPatch, target: "/api/tenders/1/edit", status: http.StatusNotFound, body: `{"status": "Error", "error": "tender not found"}`, wantErr: true},
+		{name: "undocumented status", method: http.MethodPost, target: "/api/tenders/new", status: http.StatusNotFound, body: `{"reason": "tender not found"}`, wantErr: true},
+		{name: "storage failure", method: http.MethodPost, target: "/api/tenders/new", status: http.StatusInternalServerError, body: `{"reason": "failed to create tender"}`},
+		{name: "storage failure without reason", method: http.MethodPost, target: "/api/tenders/new", status: http.StatusInternalServerError, body: `{}`, wantErr: true},
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			header := http.Header{"Content-Type": {"application/json"}}
+
+			err := spec.ValidateResponse(req, tt.status, header, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Package etag exposes tender and bid versions as entity tags, so clients
+// can make edits conditional on the version they last read.
+package etag
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Set tags the response with version.
+func Set(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// Expected returns the version an edit is based on: the one in If-Match
+// or, without the header, the one sent in the body. Zero means the client
+// did not ask for a check. If-Match "*" matches any version.
+func Expected(r *http.Request, body int) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return body, nil
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, errors.New("If-Match must be a single version in quotes")
+	}
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, errors.New("If-Match must be a single version in quotes")
+	}
+
+	if body != 0 && body != version {
+		return 0, errors.New("If-Match and version disagree")
+	}
+
+	return version, nil
+}
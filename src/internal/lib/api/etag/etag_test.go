@@ -0,0 +1,56 @@
+package etag_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"tender-app-backend/src/internal/lib/api/etag"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	etag.Set(w, 3)
+
+	if got := w.Header().Get("ETag"); got != `"3"` {
+		t.Fatalf("ETag = %s, want \"3\"", got)
+	}
+}
+
+func TestExpected(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		body    int
+		want    int
+		wantErr bool
+	}{
+		{name: "none", want: 0},
+		{name: "body", body: 2, want: 2},
+		{name: "header", ifMatch: `"3"`, want: 3},
+		{name: "header and body", ifMatch: `"3"`, body: 3, want: 3},
+		{name: "any", ifMatch: "*", want: 0},
+		{name: "disagree", ifMatch: `"3"`, body: 2, wantErr: true},
+		{name: "unquoted", ifMatch: "3", wantErr: true},
+		{name: "weak", ifMatch: `W/"3"`, wantErr: true},
+		{name: "list", ifMatch: `"3", "4"`, wantErr: true},
+		{name: "not a version", ifMatch: `"abc"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "/api/tenders/1/edit", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			got, err := etag.Expected(r, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Expected() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
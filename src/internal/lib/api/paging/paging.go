@@ -0,0 +1,38 @@
+package paging
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"tender-app-backend/src/internal"
+)
+
+const (
+	DefaultLimit = 5
+	MaxLimit     = 50
+)
+
+var ErrInvalidPage = errors.New("invalid pagination parameters")
+
+// Parse reads the limit and offset query parameters of r.
+func Parse(r *http.Request) (internal.Page, error) {
+	page := internal.Page{Limit: DefaultLimit}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 || limit > MaxLimit {
+			return internal.Page{}, ErrInvalidPage
+		}
+		page.Limit = limit
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return internal.Page{}, ErrInvalidPage
+		}
+		page.Offset = offset
+	}
+
+	return page, nil
+}
@@ -1,24 +1,133 @@
 package response
 
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/lib/logger/sl"
+	"tender-app-backend/src/internal/storage"
+)
+
+// Response is the error body described by the API spec.
 type Response struct {
-	Status string `json:"status"`
-	Error  string `json:"error,omitempty"`
+	Reason string `json:"reason"`
 }
 
-const (
-	StatusOK = "OK"
-	StatusError = "Error"
-)
+// Error is a failed request: the status code it is answered with and
+// the reason shown to the client. Err is the cause, it is only logged.
+type Error struct {
+	Code   int
+	Reason string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Reason
+	}
+
+	return e.Reason + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// BadRequest is a request the handler can not make sense of.
+func BadRequest(err error) *Error {
+	return &Error{Code: http.StatusBadRequest, Reason: "invalid request", Err: err}
+}
+
+// storageCodes maps storage and authz errors to status codes.
+var storageCodes = []struct {
+	err  error
+	code int
+}{
+	{storage.ErrUserNotFound, http.StatusUnauthorized},
+	{storage.ErrOrgRespNotFound, http.StatusForbidden},
+	{authz.ErrDenied, http.StatusForbidden},
+	{storage.ErrTenderNotFound, http.StatusNotFound},
+	{storage.ErrBidNotFound, http.StatusNotFound},
+	{storage.ErrOrganizationNotFound, http.StatusNotFound},
+	{storage.ErrVersionNotFound, http.StatusNotFound},
+	{storage.ErrNotFound, http.StatusNotFound},
+	{storage.ErrAlreadyExists, http.StatusConflict},
+	{storage.ErrLastResponsible, http.StatusConflict},
+	{storage.ErrOrganizationInUse, http.StatusConflict},
+	{storage.ErrVersionConflict, http.StatusConflict},
+	{storage.ErrTenderNotPublished, http.StatusForbidden},
+	{storage.ErrBidNotPublished, http.StatusForbidden},
+	{storage.ErrBidDeadlinePassed, http.StatusForbidden},
+	{storage.ErrInvalidTransition, http.StatusBadRequest},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout},
+}
 
-func OK() Response {
-	return Response{
-		Status: StatusOK,
+// FromStorage turns an error returned by storage or authz into an API error.
+// action completes "user is not allowed to" and "failed to" reasons.
+// Errors storage does not define are internal faults.
+func FromStorage(err error, action string) *Error {
+	for _, c := range storageCodes {
+		if !errors.Is(err, c.err) {
+			continue
+		}
+
+		reason := c.err.Error()
+		switch c.err {
+		case storage.ErrUserNotFound:
+			reason = "user does not exist"
+		case storage.ErrOrgRespNotFound, authz.ErrDenied:
+			reason = "user is not allowed to " + action
+		case context.DeadlineExceeded:
+			reason = "request timed out"
+		}
+
+		return &Error{Code: c.code, Reason: reason, Err: err}
+	}
+
+	return &Error{Code: http.StatusInternalServerError, Reason: "failed to " + action, Err: err}
+}
+
+// FromEmployeeStorage is FromStorage for requests about an employee rather
+// than made by one: a missing employee or responsible is 404, not 401 or 403.
+func FromEmployeeStorage(err error, action string) *Error {
+	switch {
+	case errors.Is(err, storage.ErrUserNotFound):
+		return &Error{Code: http.StatusNotFound, Reason: "employee not found", Err: err}
+	case errors.Is(err, storage.ErrOrgRespNotFound):
+		return &Error{Code: http.StatusNotFound, Reason: "employee is not responsible for the organization", Err: err}
+	}
+
+	return FromStorage(err, action)
+}
+
+// FromHidden is FromStorage for reading a resource the viewer may not be
+// allowed to see. A denial is answered as notFound, so that hidden ids can
+// not be told apart from missing ones.
+func FromHidden(err, notFound error, action string) *Error {
+	if errors.Is(err, authz.ErrDenied) {
+		return &Error{Code: http.StatusNotFound, Reason: notFound.Error(), Err: err}
 	}
+
+	return FromStorage(err, action)
 }
 
-func Error(msg string) Response {
-	return Response{
-		Status: StatusError,
-		Error: msg,
+// Fail logs err and answers with its status code and reason. Errors
+// other than *Error are internal faults.
+func Fail(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = &Error{Code: http.StatusInternalServerError, Reason: "internal error", Err: err}
 	}
+
+	if apiErr.Code >= http.StatusInternalServerError {
+		log.Error("request failed", slog.Int("status", apiErr.Code), sl.Err(err))
+	} else {
+		log.Info("request failed", slog.Int("status", apiErr.Code), sl.Err(err))
+	}
+
+	render.Status(r, apiErr.Code)
+	render.JSON(w, r, Response{Reason: apiErr.Reason})
 }
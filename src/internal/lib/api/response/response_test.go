@@ -0,0 +1,83 @@
+package response_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/lib/api/response"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func TestFromStorage(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   int
+		reason string
+	}{
+		{storage.ErrUserNotFound, http.StatusUnauthorized, "user does not exist"},
+		{storage.ErrOrgRespNotFound, http.StatusForbidden, "user is not allowed to edit tender"},
+		{&authz.DeniedError{Policy: "edit tender", Username: "user2"}, http.StatusForbidden, "user is not allowed to edit tender"},
+		{storage.ErrTenderNotFound, http.StatusNotFound, "tender not found"},
+		{storage.ErrBidNotFound, http.StatusNotFound, "bid not found"},
+		{storage.ErrInvalidTransition, http.StatusBadRequest, "status transition not allowed"},
+		{storage.ErrOrganizationNotFound, http.StatusNotFound, "organization not found"},
+		{storage.ErrLastResponsible, http.StatusConflict, "organization has no other responsible"},
+		{storage.ErrVersionConflict, http.StatusConflict, "version is not the current one"},
+		{storage.ErrBidDeadlinePassed, http.StatusForbidden, "tender bid deadline has passed"},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout, "request timed out"},
+		{errors.New("connection refused"), http.StatusInternalServerError, "failed to edit tender"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			err := fmt.Errorf("storage.postgres.EditTender %w", tt.err)
+
+			got := response.FromStorage(err, "edit tender")
+			if got.Code != tt.code || got.Reason != tt.reason {
+				t.Fatalf("got %d %q, want %d %q", got.Code, got.Reason, tt.code, tt.reason)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Fatalf("%v does not wrap %v", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestFromEmployeeStorage(t *testing.T) {
+	err := fmt.Errorf("storage.postgres.DeleteEmployee %w", storage.ErrUserNotFound)
+
+	got := response.FromEmployeeStorage(err, "delete employee")
+	if got.Code != http.StatusNotFound || got.Reason != "employee not found" {
+		t.Fatalf("got %d %q", got.Code, got.Reason)
+	}
+
+	got = response.FromEmployeeStorage(storage.ErrOrgRespNotFound, "remove responsible")
+	if got.Code != http.StatusNotFound || got.Reason != "employee is not responsible for the organization" {
+		t.Fatalf("got %d %q", got.Code, got.Reason)
+	}
+
+	got = response.FromEmployeeStorage(errors.New("connection refused"), "delete employee")
+	if got.Code != http.StatusInternalServerError || got.Reason != "failed to delete employee" {
+		t.Fatalf("got %d %q", got.Code, got.Reason)
+	}
+}
+
+func TestFromHidden(t *testing.T) {
+	err := &authz.DeniedError{Policy: "view tender", Username: "user3"}
+
+	got := response.FromHidden(err, storage.ErrTenderNotFound, "get tender status")
+	if got.Code != http.StatusNotFound || got.Reason != "tender not found" {
+		t.Fatalf("got %d %q", got.Code, got.Reason)
+	}
+	if !errors.Is(got, authz.ErrDenied) {
+		t.Fatalf("%v does not wrap %v", got, authz.ErrDenied)
+	}
+
+	got = response.FromHidden(errors.New("connection refused"), storage.ErrTenderNotFound, "get tender status")
+	if got.Code != http.StatusInternalServerError || got.Reason != "failed to get tender status" {
+		t.Fatalf("got %d %q", got.Code, got.Reason)
+	}
+}
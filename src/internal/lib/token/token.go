@@ -0,0 +1,69 @@
+// Package token issues and verifies the bearer tokens employees
+// authenticate with. Tokens are HMAC signed JWTs whose subject is
+// the employee id.
+package token
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"time"
+)
+
+const issuer = "tender-app"
+
+var ErrInvalid = errors.New("invalid token")
+
+type Tokens struct {
+	key []byte
+	ttl time.Duration
+}
+
+// New returns Tokens signing with key. Issued tokens expire after ttl.
+func New(key string, ttl time.Duration) *Tokens {
+	return &Tokens{key: []byte(key), ttl: ttl}
+}
+
+// Issue signs a token for the employee.
+func (t *Tokens) Issue(employeeId uuid.UUID) (string, error) {
+	const op = "token.Issue"
+
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Subject:   employeeId.String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(t.ttl)),
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.key)
+	if err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	return signed, nil
+}
+
+// Verify checks the signature and expiry of raw and returns the employee id.
+func (t *Tokens) Verify(raw string) (uuid.UUID, error) {
+	const op = "token.Verify"
+
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(raw, &claims,
+		func(*jwt.Token) (any, error) { return t.key, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s %w: %w", op, ErrInvalid, err)
+	}
+
+	employeeId, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s %w: %w", op, ErrInvalid, err)
+	}
+
+	return employeeId, nil
+}
@@ -0,0 +1,48 @@
+package token_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal/lib/token"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	employeeId := uuid.New()
+	tokens := token.New("secret", time.Hour)
+
+	valid, err := tokens.Issue(employeeId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := token.New("secret", -time.Minute).Issue(employeeId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := token.New("other", time.Hour).Issue(employeeId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tokens.Verify(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != employeeId {
+		t.Fatalf("got employee %s, want %s", got, employeeId)
+	}
+
+	for name, raw := range map[string]string{
+		"expired":   expired,
+		"other key": otherKey,
+		"garbage":   "not.a.token",
+		"tampered":  valid[:len(valid)-2] + "xx",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := tokens.Verify(raw); !errors.Is(err, token.ErrInvalid) {
+				t.Fatalf("got %v, want %v", err, token.ErrInvalid)
+			}
+		})
+	}
+}
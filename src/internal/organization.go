@@ -0,0 +1,10 @@
+package internal
+
+import "github.com/google/uuid"
+
+type Organization struct {
+	Id          uuid.UUID `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty" validate:"required,max=100"`
+	Description string    `json:"description,omitempty"`
+	Type        string    `json:"type,omitempty" validate:"omitempty,oneof=IE LLC JSC"`
+}
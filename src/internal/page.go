@@ -0,0 +1,6 @@
+package internal
+
+type Page struct {
+	Limit  int
+	Offset int
+}
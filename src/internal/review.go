@@ -0,0 +1,11 @@
+package internal
+
+import "time"
+
+type Review struct {
+	Id          int       `json:"id"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+const MaxFeedbackLength = 1000
@@ -0,0 +1,29 @@
+package internal
+
+import "time"
+
+// Revision is one version of a tender or bid: who made it and when.
+// Author is empty for versions made before authors were recorded.
+type Revision struct {
+	Version   int       `json:"version"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Change is a field that differs between two versions.
+type Change struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// changes collects the fields that differ, in the order they are checked.
+type changes []Change
+
+func (c changes) add(field, from, to string) changes {
+	if from == to {
+		return c
+	}
+
+	return append(c, Change{Field: field, From: from, To: to})
+}
@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/lib/logger/sl"
+	"time"
+)
+
+type TenderCloser interface {
+	CloseExpiredTenders(ctx context.Context, now time.Time) ([]internal.Tender, error)
+}
+
+// Run closes published tenders past their bid deadline right away and then
+// every interval, until ctx is done. Failures are logged and retried on the
+// next tick.
+func Run(ctx context.Context, log *slog.Logger, tenderCloser TenderCloser, interval time.Duration) {
+	const op = "scheduler.Run"
+
+	log = log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		closed, err := tenderCloser.CloseExpiredTenders(ctx, time.Now())
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			log.Error("failed to close expired tenders", sl.Err(err))
+		}
+
+		for _, t := range closed {
+			log.Info("tender closed past its bid deadline", slog.String("tender_id", t.Id.String()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/scheduler"
+	"testing"
+	"time"
+)
+
+type closerFunc func(ctx context.Context, now time.Time) ([]internal.Tender, error)
+
+func (f closerFunc) CloseExpiredTenders(ctx context.Context, now time.Time) ([]internal.Tender, error) {
+	return f(ctx, now)
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := make(chan time.Time, 3)
+	closer := closerFunc(func(_ context.Context, now time.Time) ([]internal.Tender, error) {
+		select {
+		case calls <- now:
+		default:
+			cancel()
+		}
+		// A failed run does not stop the scheduler.
+		return nil, errors.New("db is down")
+	})
+
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), closer, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("got %d runs before stopping, want 3", len(calls))
+	}
+
+	prev := <-calls
+	for range 2 {
+		now := <-calls
+		if !now.After(prev) {
+			t.Fatalf("run at %v is not after %v", now, prev)
+		}
+		prev = now
+	}
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+)
+
+// Event is a change to be recorded in the audit log in the same unit of
+// work as the change itself.
+type Event struct {
+	EntityType     string
+	EntityId       uuid.UUID
+	OrganizationId uuid.UUID
+	Action         string
+	Actor          string
+	// Before and After are snapshots of the entity, nil when there is
+	// nothing to show.
+	Before any
+	After  any
+}
+
+// AuditEvent marshals the snapshots of e and tags it with the id of the
+// request that made the change, if ctx has one.
+func AuditEvent(ctx context.Context, e Event) (internal.AuditEvent, error) {
+	const op = "storage.AuditEvent"
+
+	event := internal.AuditEvent{
+		Actor:          e.Actor,
+		Action:         e.Action,
+		EntityType:     e.EntityType,
+		EntityId:       e.EntityId,
+		OrganizationId: e.OrganizationId,
+		RequestId:      middleware.GetReqID(ctx),
+	}
+
+	var err error
+
+	if e.Before != nil {
+		event.Before, err = json.Marshal(e.Before)
+		if err != nil {
+			return internal.AuditEvent{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	if e.After != nil {
+		event.After, err = json.Marshal(e.After)
+		if err != nil {
+			return internal.AuditEvent{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	return event, nil
+}
+
+// TenderEvent is a change of a tender made by actor on behalf of its
+// organization, before is nil for creations.
+func TenderEvent(action, actor string, before *internal.Tender, after internal.Tender) Event {
+	e := Event{
+		EntityType:     internal.EntityTender,
+		EntityId:       after.Id,
+		OrganizationId: after.OrganizationId,
+		Action:         action,
+		Actor:          actor,
+		After:          after,
+	}
+	if before != nil {
+		e.Before = *before
+	}
+
+	return e
+}
+
+// BidEvent is a change of a bid made by actor on behalf of orgId: the
+// bidder for changes of the bid itself, the tender organization for
+// decisions. Before is nil for creations.
+func BidEvent(action, actor string, orgId uuid.UUID, before *internal.Bid, after internal.Bid) Event {
+	e := Event{
+		EntityType:     internal.EntityBid,
+		EntityId:       after.Id,
+		OrganizationId: orgId,
+		Action:         action,
+		Actor:          actor,
+		After:          after,
+	}
+	if before != nil {
+		e.Before = *before
+	}
+
+	return e
+}
+
+// EmployeeEvents is a change of an employee made by actor. It is recorded
+// for every organization in orgIds, the ones the employee is responsible
+// for, so that their responsibles see it; with no organizations it belongs
+// to uuid.Nil. Before is nil for creations and after for deletions.
+func EmployeeEvents(action, actor string, orgIds []uuid.UUID, before, after *internal.Employee) []Event {
+	if len(orgIds) == 0 {
+		orgIds = []uuid.UUID{uuid.Nil}
+	}
+
+	events := make([]Event, 0, len(orgIds))
+
+	for _, orgId := range orgIds {
+		e := Event{
+			EntityType:     internal.EntityEmployee,
+			OrganizationId: orgId,
+			Action:         action,
+			Actor:          actor,
+		}
+		if before != nil {
+			e.EntityId, e.Before = before.Id, *before
+		}
+		if after != nil {
+			e.EntityId, e.After = after.Id, *after
+		}
+		events = append(events, e)
+	}
+
+	return events
+}
+
+// OrganizationEvent is a change of an organization made by actor. Before
+// is nil for creations and after for deletions.
+func OrganizationEvent(action, actor string, before, after *internal.Organization) Event {
+	e := Event{
+		EntityType: internal.EntityOrganization,
+		Action:     action,
+		Actor:      actor,
+	}
+	if before != nil {
+		e.EntityId, e.OrganizationId, e.Before = before.Id, before.Id, *before
+	}
+	if after != nil {
+		e.EntityId, e.OrganizationId, e.After = after.Id, after.Id, *after
+	}
+
+	return e
+}
+
+// ResponsibleEvent is employee becoming a responsible of orgId, with
+// internal.ActionCreate, or ceasing to be one, with internal.ActionDelete.
+// The employee is the entity and its snapshot.
+func ResponsibleEvent(action, actor string, orgId uuid.UUID, employee internal.Employee) Event {
+	e := Event{
+		EntityType:     internal.EntityResponsible,
+		EntityId:       employee.Id,
+		OrganizationId: orgId,
+		Action:         action,
+		Actor:          actor,
+	}
+	if action == internal.ActionDelete {
+		e.Before = employee
+	} else {
+		e.After = employee
+	}
+
+	return e
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"slices"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"time"
+)
+
+// record appends e to the audit log. The caller holds the write lock.
+// Snapshots of tenders and bids always marshal, so a change is never left
+// without its event.
+func (s *Storage) record(ctx context.Context, e storage.Event) error {
+	event, err := storage.AuditEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	event.Id = int64(len(s.audit) + 1)
+	event.CreatedAt = time.Now().UTC()
+	s.audit = append(s.audit, event)
+
+	return nil
+}
+
+// recordAll records every event or, when one fails, none of them. The
+// caller holds the write lock and makes the change once they are recorded.
+func (s *Storage) recordAll(ctx context.Context, events ...storage.Event) error {
+	recorded := len(s.audit)
+
+	for _, e := range events {
+		err := s.record(ctx, e)
+		if err != nil {
+			s.audit = s.audit[:recorded]
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) GetAuditEvents(ctx context.Context, f internal.AuditFilter, page internal.Page) ([]internal.AuditEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]internal.AuditEvent, 0)
+
+	// Newest first, the log is appended to in order.
+	for _, e := range slices.Backward(s.audit) {
+		if e.OrganizationId != f.OrganizationId ||
+			f.EntityType != "" && e.EntityType != f.EntityType ||
+			f.EntityId != uuid.Nil && e.EntityId != f.EntityId ||
+			!f.From.IsZero() && e.CreatedAt.Before(f.From) ||
+			!f.To.IsZero() && !e.CreatedAt.Before(f.To) {
+			continue
+		}
+		events = append(events, e)
+	}
+
+	return paginate(events, page), nil
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"time"
+)
+
+func (s *Storage) CloseExpiredTenders(ctx context.Context, now time.Time) ([]internal.Tender, error) {
+	const op = "storage.memory.CloseExpiredTenders"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := make([]internal.Tender, 0)
+
+	for id := range s.tenders {
+		t, _ := s.tender(id)
+		if t.Status == internal.TenderPublished && !t.AcceptsBids(now) {
+			expired = append(expired, t)
+		}
+	}
+
+	slices.SortFunc(expired, func(a, b internal.Tender) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Id.String(), b.Id.String()))
+	})
+
+	closed := make([]internal.Tender, 0, len(expired))
+	recorded := len(s.audit)
+
+	// Every event is recorded before any tender is closed, so that a
+	// failure leaves both the tenders and the log as they were.
+	for _, t := range expired {
+		updated := t
+		updated.Status = internal.TenderClosed
+		updated.UpdatedAt = time.Now().UTC()
+		updated.LastEditedBy = internal.SystemActor
+
+		err := s.record(ctx, storage.TenderEvent(internal.ActionClose, internal.SystemActor, &t, updated))
+		if err != nil {
+			s.audit = s.audit[:recorded]
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+
+		closed = append(closed, updated)
+	}
+
+	for _, t := range closed {
+		e := s.tenders[t.Id]
+		e.versions[len(e.versions)-1] = t
+		e.closedByDeadline = true
+	}
+
+	return closed, nil
+}
+
+// bidDeadline refuses changes to bids of a tender past its bid deadline
+// with storage.ErrBidDeadlinePassed.
+func (s *Storage) bidDeadline(tenderId uuid.UUID) error {
+	t, err := s.tender(tenderId)
+	if err != nil {
+		return err
+	}
+
+	if !t.AcceptsBids(time.Now()) {
+		return storage.ErrBidDeadlinePassed
+	}
+
+	return nil
+}
@@ -0,0 +1,1267 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"sync"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"time"
+)
+
+// Storage keeps everything in process memory. It mirrors postgres.Storage
+// semantics and is meant for tests and local development.
+type Storage struct {
+	mu sync.RWMutex
+
+	employees     map[uuid.UUID]internal.Employee
+	organizations map[uuid.UUID]internal.Organization
+	responsibles  map[uuid.UUID]responsible
+
+	tenders   map[uuid.UUID]*tenderEntry
+	bids      map[uuid.UUID]*bidEntry
+	decisions map[uuid.UUID]map[string]string
+	feedback  []feedbackEntry
+	audit     []internal.AuditEvent
+}
+
+type responsible struct {
+	organizationId uuid.UUID
+	userId         uuid.UUID
+}
+
+// tenderEntry holds every version of a tender, the last one is the current,
+// and who made each of them.
+type tenderEntry struct {
+	versions  []internal.Tender
+	revisions []internal.Revision
+	// closedByDeadline is set while the tender is closed because its bid
+	// deadline passed, its bids can still be decided on.
+	closedByDeadline bool
+}
+
+// bidEntry holds every version of a bid, the last one is the current,
+// and who made each of them.
+type bidEntry struct {
+	versions  []internal.Bid
+	revisions []internal.Revision
+}
+
+func revision(version int, author string) internal.Revision {
+	return internal.Revision{Version: version, Author: author, CreatedAt: time.Now().UTC()}
+}
+
+type feedbackEntry struct {
+	review   internal.Review
+	bidId    uuid.UUID
+	username string
+}
+
+func New() *Storage {
+	return &Storage{
+		employees:     make(map[uuid.UUID]internal.Employee),
+		organizations: make(map[uuid.UUID]internal.Organization),
+		responsibles:  make(map[uuid.UUID]responsible),
+		tenders:       make(map[uuid.UUID]*tenderEntry),
+		bids:          make(map[uuid.UUID]*bidEntry),
+		decisions:     make(map[uuid.UUID]map[string]string),
+	}
+}
+
+func (s *Storage) Close() error {
+	return nil
+}
+
+func (s *Storage) CreateEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+	const op = "storage.memory.CreateEmployee"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.employees {
+		if existing.Username == e.Username {
+			return internal.Employee{}, fmt.Errorf("%s %w", op, storage.ErrAlreadyExists)
+		}
+	}
+
+	if e.Id == uuid.Nil {
+		e.Id = uuid.New()
+	}
+
+	err := s.recordAll(ctx, storage.EmployeeEvents(internal.ActionCreate, actor, nil, nil, &e)...)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	s.employees[e.Id] = e
+
+	return e, nil
+}
+
+func (s *Storage) GetEmployee(ctx context.Context, id uuid.UUID) (internal.Employee, error) {
+	const op = "storage.memory.GetEmployee"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.employees[id]
+	if !ok {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+	}
+
+	return e, nil
+}
+
+func (s *Storage) GetEmployeeByUsername(ctx context.Context, username string) (internal.Employee, error) {
+	const op = "storage.memory.GetEmployeeByUsername"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.employeeByUsername(username)
+	if !ok {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+	}
+
+	return e, nil
+}
+
+// GetEmployeesList returns a page of employees ordered by username.
+func (s *Storage) GetEmployeesList(ctx context.Context, page internal.Page) ([]internal.Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	employees := make([]internal.Employee, 0, len(s.employees))
+	for _, e := range s.employees {
+		employees = append(employees, e)
+	}
+	sortEmployees(employees)
+
+	return paginate(employees, page), nil
+}
+
+// EditEmployee changes the names of the employee e.Id, empty fields keep
+// their values. The username can not change, tenders and bids refer to it.
+func (s *Storage) EditEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+	const op = "storage.memory.EditEmployee"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.employees[e.Id]
+	if !ok {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+	}
+
+	edited := before
+
+	if e.FirstName != "" {
+		edited.FirstName = e.FirstName
+	}
+	if e.LastName != "" {
+		edited.LastName = e.LastName
+	}
+
+	err := s.recordAll(ctx, storage.EmployeeEvents(internal.ActionEdit, actor, s.employeeOrgs(e.Id), &before, &edited)...)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	s.employees[e.Id] = edited
+
+	return edited, nil
+}
+
+// DeleteEmployee removes the employee along with their responsibilities.
+// An organization can not be left without a responsible this way.
+func (s *Storage) DeleteEmployee(ctx context.Context, id uuid.UUID, actor string) error {
+	const op = "storage.memory.DeleteEmployee"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.employees[id]
+	if !ok {
+		return fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+	}
+
+	for _, r := range s.responsibles {
+		if r.userId == id && s.orgRespCount(r.organizationId) <= 1 {
+			return fmt.Errorf("%s %w", op, storage.ErrLastResponsible)
+		}
+	}
+
+	err := s.recordAll(ctx, storage.EmployeeEvents(internal.ActionDelete, actor, s.employeeOrgs(id), &e, nil)...)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	for respId, r := range s.responsibles {
+		if r.userId == id {
+			delete(s.responsibles, respId)
+		}
+	}
+	delete(s.employees, id)
+
+	return nil
+}
+
+// CreateOrganization stores o and makes the given employees its responsibles.
+func (s *Storage) CreateOrganization(ctx context.Context, o internal.Organization, actor string, responsibles ...string) (internal.Organization, error) {
+	const op = "storage.memory.CreateOrganization"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o.Id == uuid.Nil {
+		o.Id = uuid.New()
+	}
+	if _, ok := s.organizations[o.Id]; ok {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, storage.ErrAlreadyExists)
+	}
+
+	added := make(map[uuid.UUID]responsible)
+	events := []storage.Event{storage.OrganizationEvent(internal.ActionCreate, actor, nil, &o)}
+	for _, username := range responsibles {
+		e, ok := s.employeeByUsername(username)
+		if !ok {
+			return internal.Organization{}, fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+		}
+		added[uuid.New()] = responsible{organizationId: o.Id, userId: e.Id}
+		events = append(events, storage.ResponsibleEvent(internal.ActionCreate, actor, o.Id, e))
+	}
+
+	err := s.recordAll(ctx, events...)
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	s.organizations[o.Id] = o
+	for id, r := range added {
+		s.responsibles[id] = r
+	}
+
+	return o, nil
+}
+
+func (s *Storage) GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error) {
+	const op = "storage.memory.GetOrganization"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	o, ok := s.organizations[orgId]
+	if !ok {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, storage.ErrOrganizationNotFound)
+	}
+
+	return o, nil
+}
+
+// GetOrganizationsList returns a page of organizations ordered by name.
+func (s *Storage) GetOrganizationsList(ctx context.Context, page internal.Page) ([]internal.Organization, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	organizations := make([]internal.Organization, 0, len(s.organizations))
+	for _, o := range s.organizations {
+		organizations = append(organizations, o)
+	}
+	slices.SortFunc(organizations, func(a, b internal.Organization) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Id.String(), b.Id.String()))
+	})
+
+	return paginate(organizations, page), nil
+}
+
+// EditOrganization changes the organization o.Id, empty fields keep their values.
+func (s *Storage) EditOrganization(ctx context.Context, o internal.Organization, actor string) (internal.Organization, error) {
+	const op = "storage.memory.EditOrganization"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.organizations[o.Id]
+	if !ok {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, storage.ErrOrganizationNotFound)
+	}
+
+	edited := before
+
+	if o.Name != "" {
+		edited.Name = o.Name
+	}
+	if o.Description != "" {
+		edited.Description = o.Description
+	}
+	if o.Type != "" {
+		edited.Type = o.Type
+	}
+
+	err := s.recordAll(ctx, storage.OrganizationEvent(internal.ActionEdit, actor, &before, &edited))
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	s.organizations[o.Id] = edited
+
+	return edited, nil
+}
+
+// DeleteOrganization removes an organization that has neither tenders nor bids.
+func (s *Storage) DeleteOrganization(ctx context.Context, orgId uuid.UUID, actor string) error {
+	const op = "storage.memory.DeleteOrganization"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.organizations[orgId]
+	if !ok {
+		return fmt.Errorf("%s %w", op, storage.ErrOrganizationNotFound)
+	}
+
+	for _, t := range s.tenders {
+		if t.versions[len(t.versions)-1].OrganizationId == orgId {
+			return fmt.Errorf("%s %w", op, storage.ErrOrganizationInUse)
+		}
+	}
+	for _, b := range s.bids {
+		if b.versions[len(b.versions)-1].OrganizationId == orgId {
+			return fmt.Errorf("%s %w", op, storage.ErrOrganizationInUse)
+		}
+	}
+
+	err := s.recordAll(ctx, storage.OrganizationEvent(internal.ActionDelete, actor, &o, nil))
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	for respId, r := range s.responsibles {
+		if r.organizationId == orgId {
+			delete(s.responsibles, respId)
+		}
+	}
+	delete(s.organizations, orgId)
+
+	return nil
+}
+
+// GetOrganizationResponsibles returns a page of the organization
+// responsibles ordered by username.
+func (s *Storage) GetOrganizationResponsibles(ctx context.Context, orgId uuid.UUID, page internal.Page) ([]internal.Employee, error) {
+	const op = "storage.memory.GetOrganizationResponsibles"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.organizations[orgId]; !ok {
+		return nil, fmt.Errorf("%s %w", op, storage.ErrOrganizationNotFound)
+	}
+
+	employees := make([]internal.Employee, 0)
+	for _, r := range s.responsibles {
+		if r.organizationId == orgId {
+			employees = append(employees, s.employees[r.userId])
+		}
+	}
+	sortEmployees(employees)
+
+	return paginate(employees, page), nil
+}
+
+func (s *Storage) AddOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error {
+	const op = "storage.memory.AddOrganizationResponsible"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.organizations[orgId]; !ok {
+		return fmt.Errorf("%s %w", op, storage.ErrOrganizationNotFound)
+	}
+
+	_, err := s.orgRespId(orgId, username)
+	if err == nil {
+		return fmt.Errorf("%s %w", op, storage.ErrAlreadyExists)
+	}
+	if !errors.Is(err, storage.ErrOrgRespNotFound) {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	e, ok := s.employeeByUsername(username)
+	if !ok {
+		return fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+	}
+
+	err = s.recordAll(ctx, storage.ResponsibleEvent(internal.ActionCreate, actor, orgId, e))
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	s.responsibles[uuid.New()] = responsible{organizationId: orgId, userId: e.Id}
+
+	return nil
+}
+
+// RemoveOrganizationResponsible takes the responsibility away from username.
+// The last responsible of an organization can not be removed.
+func (s *Storage) RemoveOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error {
+	const op = "storage.memory.RemoveOrganizationResponsible"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.organizations[orgId]; !ok {
+		return fmt.Errorf("%s %w", op, storage.ErrOrganizationNotFound)
+	}
+
+	respId, err := s.orgRespId(orgId, username)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if s.orgRespCount(orgId) <= 1 {
+		return fmt.Errorf("%s %w", op, storage.ErrLastResponsible)
+	}
+
+	e := s.employees[s.responsibles[respId].userId]
+
+	err = s.recordAll(ctx, storage.ResponsibleEvent(internal.ActionDelete, actor, orgId, e))
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	delete(s.responsibles, respId)
+
+	return nil
+}
+
+func (s *Storage) GetOrgRespId(ctx context.Context, orgId uuid.UUID, creatorUsername string) (uuid.UUID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.orgRespId(orgId, creatorUsername)
+}
+
+func (s *Storage) IsOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username string) (bool, error) {
+	const op = "storage.memory.IsOrganizationResponsible"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, err := s.orgRespId(orgId, username)
+	if errors.Is(err, storage.ErrOrgRespNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s %w", op, err)
+	}
+
+	return true, nil
+}
+
+func (s *Storage) employeeByUsername(username string) (internal.Employee, bool) {
+	for _, e := range s.employees {
+		if e.Username == username {
+			return e, true
+		}
+	}
+
+	return internal.Employee{}, false
+}
+
+func sortEmployees(employees []internal.Employee) {
+	slices.SortFunc(employees, func(a, b internal.Employee) int {
+		return cmp.Compare(a.Username, b.Username)
+	})
+}
+
+func (s *Storage) orgRespId(orgId uuid.UUID, username string) (uuid.UUID, error) {
+	e, ok := s.employeeByUsername(username)
+	if !ok && username != "" {
+		return uuid.Nil, storage.ErrUserNotFound
+	}
+	if !ok {
+		return uuid.Nil, storage.ErrOrgRespNotFound
+	}
+
+	for id, r := range s.responsibles {
+		if r.organizationId == orgId && r.userId == e.Id {
+			return id, nil
+		}
+	}
+
+	return uuid.Nil, storage.ErrOrgRespNotFound
+}
+
+// employeeOrgs lists the organizations the employee is responsible for,
+// ordered like postgres orders them.
+func (s *Storage) employeeOrgs(userId uuid.UUID) []uuid.UUID {
+	orgIds := make([]uuid.UUID, 0)
+	for _, r := range s.responsibles {
+		if r.userId == userId {
+			orgIds = append(orgIds, r.organizationId)
+		}
+	}
+	slices.SortFunc(orgIds, func(a, b uuid.UUID) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+
+	return orgIds
+}
+
+func (s *Storage) orgRespCount(orgId uuid.UUID) int {
+	count := 0
+	for _, r := range s.responsibles {
+		if r.organizationId == orgId {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (s *Storage) CreateTender(ctx context.Context, t internal.Tender) (internal.Tender, error) {
+	const op = "storage.memory.CreateTender"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.orgRespId(t.OrganizationId, t.CreatorUsername)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	r := revision(1, t.CreatorUsername)
+
+	t.Id = uuid.New()
+	t.Version = 1
+	t.Status = internal.TenderCreated
+	t.CreatedAt = r.CreatedAt
+	t.UpdatedAt = r.CreatedAt
+	t.LastEditedBy = t.CreatorUsername
+
+	err = s.record(ctx, storage.TenderEvent(internal.ActionCreate, t.CreatorUsername, nil, t))
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	s.tenders[t.Id] = &tenderEntry{
+		versions:  []internal.Tender{t},
+		revisions: []internal.Revision{r},
+	}
+
+	return t, nil
+}
+
+func (s *Storage) GetTender(ctx context.Context, tenderId uuid.UUID) (internal.Tender, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.tender(tenderId)
+}
+
+func (s *Storage) tender(tenderId uuid.UUID) (internal.Tender, error) {
+	e, ok := s.tenders[tenderId]
+	if !ok {
+		return internal.Tender{}, storage.ErrTenderNotFound
+	}
+
+	return e.versions[len(e.versions)-1], nil
+}
+
+// setTenderStatus changes the status of the current version on behalf
+// of username and returns the updated tender.
+func (s *Storage) setTenderStatus(tenderId uuid.UUID, status, username string) internal.Tender {
+	e := s.tenders[tenderId]
+	t := &e.versions[len(e.versions)-1]
+	t.Status = status
+	t.UpdatedAt = time.Now().UTC()
+	t.LastEditedBy = username
+
+	return *t
+}
+
+// tenderVisible mirrors the postgres visibility rule for tenders.
+func (s *Storage) tenderVisible(v internal.Viewer, t internal.Tender) error {
+	if t.Status == internal.TenderPublished {
+		return nil
+	}
+
+	_, err := s.orgRespId(t.OrganizationId, v.Username)
+
+	return err
+}
+
+func (s *Storage) UpdateTenderStatus(ctx context.Context, tenderId uuid.UUID, status, username string) (internal.Tender, error) {
+	const op = "storage.memory.UpdateTenderStatus"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.tender(tenderId)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = s.orgRespId(t.OrganizationId, username)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if !internal.CanChangeTenderStatus(t.Status, status) {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, storage.ErrInvalidTransition)
+	}
+
+	updated := s.setTenderStatus(tenderId, status, username)
+
+	err = s.record(ctx, storage.TenderEvent(internal.StatusAction(status), username, &t, updated))
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func (s *Storage) GetTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.tendersList(v, serviceTypes, page, func(internal.Tender) bool { return true }), nil
+}
+
+func (s *Storage) GetUserTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.tendersList(v, serviceTypes, page, func(t internal.Tender) bool {
+		return t.CreatorUsername == v.Username
+	}), nil
+}
+
+func (s *Storage) tendersList(v internal.Viewer, serviceTypes []string, page internal.Page, match func(internal.Tender) bool) []internal.Tender {
+	tenders := make([]internal.Tender, 0)
+
+	for id := range s.tenders {
+		t, _ := s.tender(id)
+		if !match(t) || s.tenderVisible(v, t) != nil {
+			continue
+		}
+		if len(serviceTypes) > 0 && !slices.Contains(serviceTypes, t.ServiceType) {
+			continue
+		}
+		tenders = append(tenders, t)
+	}
+
+	slices.SortFunc(tenders, func(a, b internal.Tender) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Id.String(), b.Id.String()))
+	})
+
+	return paginate(tenders, page)
+}
+
+func (s *Storage) EditTender(ctx context.Context, t internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error) {
+	const op = "storage.memory.EditTender"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	edit, err := s.editTender(ctx, internal.ActionEdit, t, editId, expected, username)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return edit, nil
+}
+
+func (s *Storage) editTender(ctx context.Context, action string, t internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error) {
+	before, err := s.tender(editId)
+	if err != nil {
+		return internal.Tender{}, err
+	}
+
+	edit := before
+
+	if expected != 0 && expected != edit.Version {
+		return internal.Tender{}, storage.ErrVersionConflict
+	}
+
+	if t.Name != "" {
+		edit.Name = t.Name
+	}
+	if t.Description != "" {
+		edit.Description = t.Description
+	}
+	if t.ServiceType != "" {
+		edit.ServiceType = t.ServiceType
+	}
+	// A rollback restores the deadline even when the version had none.
+	if t.BidDeadline != nil || action == internal.ActionRollback {
+		edit.BidDeadline = t.BidDeadline
+	}
+
+	e := s.tenders[editId]
+	r := revision(len(e.versions)+1, username)
+	edit.Version = r.Version
+	edit.UpdatedAt = r.CreatedAt
+	edit.LastEditedBy = username
+
+	err = s.record(ctx, storage.TenderEvent(action, username, &before, edit))
+	if err != nil {
+		return internal.Tender{}, err
+	}
+
+	e.versions = append(e.versions, edit)
+	e.revisions = append(e.revisions, r)
+
+	return edit, nil
+}
+
+func (s *Storage) RollbackTender(ctx context.Context, tenderId uuid.UUID, version, expected int, username string) (internal.Tender, error) {
+	const op = "storage.memory.RollbackTender"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.tenders[tenderId]
+	if !ok || version < 1 || version > len(e.versions) {
+		return internal.Tender{}, storage.ErrTenderNotFound
+	}
+
+	rolledBack, err := s.editTender(ctx, internal.ActionRollback, e.versions[version-1], tenderId, expected, username)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return rolledBack, nil
+}
+
+func (s *Storage) GetTenderRevisions(ctx context.Context, tenderId uuid.UUID, page internal.Page) ([]internal.Revision, error) {
+	const op = "storage.memory.GetTenderRevisions"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.tenders[tenderId]
+	if !ok {
+		return nil, fmt.Errorf("%s %w", op, storage.ErrTenderNotFound)
+	}
+
+	return slices.Clone(paginate(e.revisions, page)), nil
+}
+
+func (s *Storage) GetTenderRevision(ctx context.Context, tenderId uuid.UUID, version int) (internal.Tender, error) {
+	const op = "storage.memory.GetTenderRevision"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.tenders[tenderId]
+	if !ok {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, storage.ErrTenderNotFound)
+	}
+	if version < 1 || version > len(e.versions) {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, storage.ErrVersionNotFound)
+	}
+
+	// Status changes update the current version in place, the revision
+	// still tells who made the version and when.
+	t := e.versions[version-1]
+	t.UpdatedAt = e.revisions[version-1].CreatedAt
+	t.LastEditedBy = e.revisions[version-1].Author
+
+	return t, nil
+}
+
+func (s *Storage) CreateBid(ctx context.Context, b internal.Bid) (internal.Bid, error) {
+	const op = "storage.memory.CreateBid"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.orgRespId(b.OrganizationId, b.CreatorUsername)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.bidDeadline(b.TenderId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	r := revision(1, b.CreatorUsername)
+
+	b.Id = uuid.New()
+	b.Version = 1
+	b.Status = internal.BidCreated
+	b.CreatedAt = r.CreatedAt
+	b.UpdatedAt = r.CreatedAt
+	b.LastEditedBy = b.CreatorUsername
+
+	err = s.record(ctx, storage.BidEvent(internal.ActionCreate, b.CreatorUsername, b.OrganizationId, nil, b))
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	s.bids[b.Id] = &bidEntry{
+		versions:  []internal.Bid{b},
+		revisions: []internal.Revision{r},
+	}
+
+	return b, nil
+}
+
+func (s *Storage) GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.bid(bidId)
+}
+
+func (s *Storage) bid(bidId uuid.UUID) (internal.Bid, error) {
+	e, ok := s.bids[bidId]
+	if !ok {
+		return internal.Bid{}, storage.ErrBidNotFound
+	}
+
+	return e.versions[len(e.versions)-1], nil
+}
+
+// setBidStatus changes the status of the current version on behalf
+// of username and returns the updated bid.
+func (s *Storage) setBidStatus(bidId uuid.UUID, status, username string) internal.Bid {
+	e := s.bids[bidId]
+	b := &e.versions[len(e.versions)-1]
+	b.Status = status
+	b.UpdatedAt = time.Now().UTC()
+	b.LastEditedBy = username
+
+	return *b
+}
+
+// bidAuthor reports whether username is the bid author
+// or a responsible of the author organization.
+func (s *Storage) bidAuthor(b internal.Bid, username string) error {
+	if b.CreatorUsername == username {
+		return nil
+	}
+
+	_, err := s.orgRespId(b.OrganizationId, username)
+
+	return err
+}
+
+// tenderResp reports whether username is a responsible
+// of the organization that owns the tender.
+func (s *Storage) tenderResp(tenderId uuid.UUID, username string) error {
+	t, err := s.tender(tenderId)
+	if err != nil {
+		return storage.ErrOrgRespNotFound
+	}
+
+	_, err = s.orgRespId(t.OrganizationId, username)
+
+	return err
+}
+
+// bidVisible mirrors the postgres visibility rule for bids.
+func (s *Storage) bidVisible(v internal.Viewer, b internal.Bid) error {
+	err := s.bidAuthor(b, v.Username)
+	if err == nil || b.Status == internal.BidCreated {
+		return err
+	}
+
+	return s.tenderResp(b.TenderId, v.Username)
+}
+
+func (s *Storage) UpdateBidStatus(ctx context.Context, bidId uuid.UUID, status, username string) (internal.Bid, error) {
+	const op = "storage.memory.UpdateBidStatus"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := s.bid(bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.bidAuthor(b, username)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if internal.IsBidOutcome(status) || !internal.CanChangeBidStatus(b.Status, status) {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrInvalidTransition)
+	}
+
+	if status == internal.BidPublished {
+		err = s.bidDeadline(b.TenderId)
+		if err != nil {
+			return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	updated := s.setBidStatus(bidId, status, username)
+
+	err = s.record(ctx, storage.BidEvent(internal.StatusAction(status), username, b.OrganizationId, &b, updated))
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func (s *Storage) EditBid(ctx context.Context, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error) {
+	const op = "storage.memory.EditBid"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	edit, err := s.editBid(ctx, internal.ActionEdit, b, editId, expected, username)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return edit, nil
+}
+
+func (s *Storage) editBid(ctx context.Context, action string, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error) {
+	before, err := s.bid(editId)
+	if err != nil {
+		return internal.Bid{}, err
+	}
+
+	edit := before
+
+	if expected != 0 && expected != edit.Version {
+		return internal.Bid{}, storage.ErrVersionConflict
+	}
+
+	err = s.bidDeadline(edit.TenderId)
+	if err != nil {
+		return internal.Bid{}, err
+	}
+
+	if b.Name != "" {
+		edit.Name = b.Name
+	}
+	if b.Description != "" {
+		edit.Description = b.Description
+	}
+
+	e := s.bids[editId]
+	r := revision(len(e.versions)+1, username)
+	edit.Version = r.Version
+	edit.UpdatedAt = r.CreatedAt
+	edit.LastEditedBy = username
+
+	err = s.record(ctx, storage.BidEvent(action, username, edit.OrganizationId, &before, edit))
+	if err != nil {
+		return internal.Bid{}, err
+	}
+
+	e.versions = append(e.versions, edit)
+	e.revisions = append(e.revisions, r)
+
+	return edit, nil
+}
+
+func (s *Storage) RollbackBid(ctx context.Context, bidId uuid.UUID, version, expected int, username string) (internal.Bid, error) {
+	const op = "storage.memory.RollbackBid"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.bids[bidId]
+	if !ok || version < 1 || version > len(e.versions) {
+		return internal.Bid{}, storage.ErrBidNotFound
+	}
+
+	rolledBack, err := s.editBid(ctx, internal.ActionRollback, e.versions[version-1], bidId, expected, username)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return rolledBack, nil
+}
+
+func (s *Storage) GetBidRevisions(ctx context.Context, bidId uuid.UUID, page internal.Page) ([]internal.Revision, error) {
+	const op = "storage.memory.GetBidRevisions"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.bids[bidId]
+	if !ok {
+		return nil, fmt.Errorf("%s %w", op, storage.ErrBidNotFound)
+	}
+
+	return slices.Clone(paginate(e.revisions, page)), nil
+}
+
+func (s *Storage) GetBidRevision(ctx context.Context, bidId uuid.UUID, version int) (internal.Bid, error) {
+	const op = "storage.memory.GetBidRevision"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.bids[bidId]
+	if !ok {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrBidNotFound)
+	}
+	if version < 1 || version > len(e.versions) {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrVersionNotFound)
+	}
+
+	// Status changes update the current version in place, the revision
+	// still tells who made the version and when.
+	b := e.versions[version-1]
+	b.UpdatedAt = e.revisions[version-1].CreatedAt
+	b.LastEditedBy = e.revisions[version-1].Author
+
+	return b, nil
+}
+
+func (s *Storage) GetUserBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.bidsList(page, func(b internal.Bid) bool {
+		return b.CreatorUsername == v.Username
+	}), nil
+}
+
+func (s *Storage) GetTenderBidsList(ctx context.Context, v internal.Viewer, tenderId uuid.UUID, page internal.Page) ([]internal.Bid, error) {
+	const op = "storage.memory.GetTenderBidsList"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, err := s.tender(tenderId)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.tenderVisible(v, t)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return s.bidsList(page, func(b internal.Bid) bool {
+		return b.TenderId == tenderId && s.bidVisible(v, b) == nil
+	}), nil
+}
+
+func (s *Storage) GetBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.bidsList(page, func(b internal.Bid) bool {
+		return s.bidVisible(v, b) == nil
+	}), nil
+}
+
+func (s *Storage) bidsList(page internal.Page, match func(internal.Bid) bool) []internal.Bid {
+	bids := make([]internal.Bid, 0)
+
+	for id := range s.bids {
+		b, _ := s.bid(id)
+		if match(b) {
+			bids = append(bids, b)
+		}
+	}
+
+	slices.SortFunc(bids, func(a, b internal.Bid) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Id.String(), b.Id.String()))
+	})
+
+	return paginate(bids, page)
+}
+
+func (s *Storage) SubmitBid(ctx context.Context, bidId uuid.UUID, decision, orgUsername string) (internal.Bid, error) {
+	const op = "storage.memory.SubmitBid"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := s.bid(bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if b.Status != internal.BidPublished {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrBidNotPublished)
+	}
+
+	t, err := s.tender(b.TenderId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if t.Status != internal.TenderPublished && !s.tenders[t.Id].closedByDeadline {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrTenderNotPublished)
+	}
+
+	_, err = s.orgRespId(t.OrganizationId, orgUsername)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if s.decisions[bidId] == nil {
+		s.decisions[bidId] = make(map[string]string)
+	}
+	s.decisions[bidId][orgUsername] = decision
+
+	if decision == internal.DecisionRejected {
+		s.setBidStatus(bidId, internal.BidRejected, orgUsername)
+
+		return s.recordDecision(ctx, decision, orgUsername, t, b)
+	}
+
+	// Decisions of those who are no longer responsible do not count.
+	approvals := 0
+	for username, d := range s.decisions[bidId] {
+		if d != internal.DecisionApproved {
+			continue
+		}
+		if _, err := s.orgRespId(t.OrganizationId, username); err == nil {
+			approvals++
+		}
+	}
+
+	if approvals >= internal.DecisionQuorum(s.orgRespCount(t.OrganizationId)) {
+		s.setBidStatus(bidId, internal.BidApproved, orgUsername)
+
+		// The approval settles a tender closed by its deadline too.
+		s.tenders[t.Id].closedByDeadline = false
+
+		if t.Status == internal.TenderPublished {
+			closed := s.setTenderStatus(t.Id, internal.TenderClosed, orgUsername)
+
+			err = s.record(ctx, storage.TenderEvent(internal.ActionClose, orgUsername, &t, closed))
+			if err != nil {
+				return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+			}
+		}
+	}
+
+	return s.recordDecision(ctx, decision, orgUsername, t, b)
+}
+
+// recordDecision records the decision on behalf of the tender organization
+// and returns the bid as it is after it.
+func (s *Storage) recordDecision(ctx context.Context, decision, orgUsername string, t internal.Tender, before internal.Bid) (internal.Bid, error) {
+	const op = "storage.memory.recordDecision"
+
+	after, _ := s.bid(before.Id)
+
+	err := s.record(ctx, storage.BidEvent(internal.DecisionAction(decision, after.Status), orgUsername, t.OrganizationId, &before, after))
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return after, nil
+}
+
+func (s *Storage) SubmitBidFeedback(ctx context.Context, bidId uuid.UUID, feedback, username string) (internal.Bid, error) {
+	const op = "storage.memory.SubmitBidFeedback"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := s.bid(bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.tenderResp(b.TenderId, username)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	// Drafts are not shown to the tender organization yet.
+	if b.Status == internal.BidCreated {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrBidNotPublished)
+	}
+
+	review := internal.Review{
+		Id:          len(s.feedback) + 1,
+		Description: feedback,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	t, _ := s.tender(b.TenderId)
+
+	err = s.record(ctx, storage.Event{
+		EntityType:     internal.EntityBid,
+		EntityId:       bidId,
+		OrganizationId: t.OrganizationId,
+		Action:         internal.ActionFeedback,
+		Actor:          username,
+		After:          review,
+	})
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	s.feedback = append(s.feedback, feedbackEntry{
+		review:   review,
+		bidId:    bidId,
+		username: username,
+	})
+
+	return b, nil
+}
+
+func (s *Storage) GetBidReviews(ctx context.Context, tenderId uuid.UUID, authorUsername, requesterUsername string, page internal.Page) ([]internal.Review, error) {
+	const op = "storage.memory.GetBidReviews"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, err := s.tender(tenderId)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.tenderResp(tenderId, requesterUsername)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	authorBid := false
+	for id := range s.bids {
+		b, _ := s.bid(id)
+		if b.TenderId == tenderId && b.CreatorUsername == authorUsername {
+			authorBid = true
+			break
+		}
+	}
+	if !authorBid {
+		return nil, fmt.Errorf("%s %w", op, storage.ErrBidNotFound)
+	}
+
+	reviews := make([]internal.Review, 0)
+	for i := len(s.feedback) - 1; i >= 0; i-- {
+		f := s.feedback[i]
+		b, err := s.bid(f.bidId)
+		if err == nil && b.CreatorUsername == authorUsername {
+			reviews = append(reviews, f.review)
+		}
+	}
+
+	return paginate(reviews, page), nil
+}
+
+func paginate[T any](items []T, page internal.Page) []T {
+	if page.Offset >= len(items) {
+		return items[:0]
+	}
+
+	end := min(page.Offset+page.Limit, len(items))
+
+	return items[page.Offset:end]
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"tender-app-backend/src/internal"
+)
+
+// Seed is the fixture format read by LoadSeed. Employees and organizations
+// are managed through the API, but tokens are only issued to existing
+// employees, so at least the first one has to be provided up front. Seeded
+// changes are audited on behalf of internal.SystemActor.
+//
+// Employees must come with an id: tokens name the employee by id, and the
+// server and the token command each load their own copy of the seed.
+type Seed struct {
+	Employees     []internal.Employee     `json:"employees"`
+	Organizations []internal.Organization `json:"organizations"`
+	Responsibles  []SeedResponsible       `json:"responsibles"`
+}
+
+type SeedResponsible struct {
+	OrganizationId uuid.UUID `json:"organizationId"`
+	Username       string    `json:"username"`
+}
+
+func (s *Storage) LoadSeed(ctx context.Context, path string) error {
+	const op = "storage.memory.LoadSeed"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	var seed Seed
+	err = json.Unmarshal(data, &seed)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	for _, e := range seed.Employees {
+		if e.Id == uuid.Nil {
+			return fmt.Errorf("%s employee %q has no id", op, e.Username)
+		}
+		if _, err := s.CreateEmployee(ctx, e, internal.SystemActor); err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	for _, o := range seed.Organizations {
+		if _, err := s.CreateOrganization(ctx, o, internal.SystemActor); err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	for _, r := range seed.Responsibles {
+		if err := s.AddOrganizationResponsible(ctx, r.OrganizationId, r.Username, internal.SystemActor); err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	return nil
+}
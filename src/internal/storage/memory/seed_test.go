@@ -0,0 +1,84 @@
+package memory_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"tender-app-backend/src/internal/http-server/middleware/auth"
+	"tender-app-backend/src/internal/lib/token"
+	"tender-app-backend/src/internal/storage/memory"
+	"testing"
+	"time"
+)
+
+const seed = `{
+  "employees": [{"id": "4c3f9a2e-8b1d-4f6a-9c2e-7d5b3a1f0e92", "username": "user1"}],
+  "organizations": [{"id": "550e8400-e29b-41d4-a716-446655440000", "name": "Org"}],
+  "responsibles": [{"organizationId": "550e8400-e29b-41d4-a716-446655440000", "username": "user1"}]
+}`
+
+// TestSeedToken issues a token from one store and authenticates it against
+// another loaded from the same seed, as `tender-app token` and the server do.
+func TestSeedToken(t *testing.T) {
+	path := writeSeed(t, seed)
+	ctx := context.Background()
+
+	issuer := memory.New()
+	if err := issuer.LoadSeed(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := issuer.GetEmployeeByUsername(ctx, "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := token.New("secret", time.Hour)
+
+	raw, err := tokens.Issue(e.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := memory.New()
+	if err = server.LoadSeed(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+
+	var actor string
+	handler := auth.New(slog.New(slog.NewTextHandler(io.Discard, nil)), tokens, server)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor, _ = auth.Actor(r, "")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+raw)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || actor != "user1" {
+		t.Fatalf("got status %d, actor %q, want 200 and user1: %s", rec.Code, actor, rec.Body)
+	}
+}
+
+func TestLoadSeedWithoutEmployeeId(t *testing.T) {
+	path := writeSeed(t, `{"employees": [{"username": "user1"}]}`)
+
+	if err := memory.New().LoadSeed(context.Background(), path); err == nil {
+		t.Fatal("loaded an employee without an id")
+	}
+}
+
+func writeSeed(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
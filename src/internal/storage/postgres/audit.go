@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"time"
+)
+
+// recordAudit appends e to the audit log. It is called from the
+// transaction making the change, so the event is kept only if the
+// change is.
+func (s *Storage) recordAudit(ctx context.Context, e storage.Event) error {
+	const op = "storage.postgres.recordAudit"
+
+	event, err := storage.AuditEvent(ctx, e)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		INSERT INTO audit_event(actor, action, entity_type, entity_id, organization_id, before, after, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
+	`)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, event.Actor, event.Action, event.EntityType, event.EntityId, event.OrganizationId,
+		jsonb(event.Before), jsonb(event.After), event.RequestId)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// GetAuditEvents lists the events of the filter organization, newest first.
+func (s *Storage) GetAuditEvents(ctx context.Context, f internal.AuditFilter, page internal.Page) ([]internal.AuditEvent, error) {
+	const op = "storage.postgres.GetAuditEvents"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT id, actor, action, entity_type, entity_id, organization_id, before, after, COALESCE(request_id, ''), created_at
+		FROM audit_event
+		WHERE organization_id = $1
+		  AND ($2::text = '' OR entity_type = $2)
+		  AND ($3::uuid IS NULL OR entity_id = $3)
+		  AND ($4::timestamptz IS NULL OR created_at >= $4)
+		  AND ($5::timestamptz IS NULL OR created_at < $5)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $6 OFFSET $7
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, f.OrganizationId, f.EntityType, nullUUID(f.EntityId), nullTime(f.From), nullTime(f.To), page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+	defer rows.Close()
+
+	events := make([]internal.AuditEvent, 0)
+
+	for rows.Next() {
+		var (
+			e             internal.AuditEvent
+			before, after []byte
+		)
+		err = rows.Scan(&e.Id, &e.Actor, &e.Action, &e.EntityType, &e.EntityId, &e.OrganizationId, &before, &after, &e.RequestId, &e.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+		e.Before, e.After = before, after
+		events = append(events, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return events, nil
+}
+
+// jsonb passes a snapshot as text, the driver would send bytes as bytea.
+func jsonb(raw json.RawMessage) any {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return string(raw)
+}
+
+func nullUUID(id uuid.UUID) any {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return id
+}
+
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t
+}
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"time"
+)
+
+// expiryLockKey identifies the advisory lock held while expired tenders
+// are closed, so that replicas running the scheduler take turns.
+const expiryLockKey = 4829105732
+
+// CloseExpiredTenders closes the published tenders whose bid deadline is
+// not after now and returns them. When another replica is closing tenders
+// already, it returns none.
+func (s *Storage) CloseExpiredTenders(ctx context.Context, now time.Time) ([]internal.Tender, error) {
+	var closed []internal.Tender
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		closed, err = tx.closeExpiredTenders(ctx, now)
+		return err
+	})
+
+	return closed, err
+}
+
+func (s *Storage) closeExpiredTenders(ctx context.Context, now time.Time) ([]internal.Tender, error) {
+	const op = "storage.postgres.CloseExpiredTenders"
+
+	lock, err := s.prepare(ctx, "SELECT pg_try_advisory_xact_lock($1)")
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	var locked bool
+
+	err = lock.QueryRowContext(ctx, expiryLockKey).Scan(&locked)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	closed := make([]internal.Tender, 0)
+
+	if !locked {
+		return closed, nil
+	}
+
+	ids, err := s.expiredTenders(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	for _, id := range ids {
+		t, err := s.closeExpiredTender(ctx, id, now)
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+		if t.Status == internal.TenderClosed {
+			closed = append(closed, t)
+		}
+	}
+
+	return closed, nil
+}
+
+// expiredTenders lists the published tenders past their bid deadline.
+func (s *Storage) expiredTenders(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	const op = "storage.postgres.expiredTenders"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT r.id
+		FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
+		JOIN status AS s ON t.status_id = s.id
+		WHERE s.status_type = 'PUBLISHED' AND t.bid_deadline <= $1
+		ORDER BY t.name, r.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+
+	for rows.Next() {
+		var id uuid.UUID
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// closeExpiredTender closes the tender unless it changed since it was
+// found expired, and returns it either way.
+func (s *Storage) closeExpiredTender(ctx context.Context, tenderId uuid.UUID, now time.Time) (internal.Tender, error) {
+	const op = "storage.postgres.closeExpiredTender"
+
+	err := s.lockTender(ctx, tenderId)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	t, err := s.GetTender(ctx, tenderId)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if t.Status != internal.TenderPublished || t.AcceptsBids(now) {
+		return t, nil
+	}
+
+	err = s.CloseTender(ctx, tenderId)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.setClosedByDeadline(ctx, tenderId, true)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	before := t
+
+	t.UpdatedAt, err = s.touchTender(ctx, tenderId, internal.SystemActor)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	t.Status = internal.TenderClosed
+	t.LastEditedBy = internal.SystemActor
+
+	err = s.recordAudit(ctx, storage.TenderEvent(internal.ActionClose, internal.SystemActor, &before, t))
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return t, nil
+}
+
+// setClosedByDeadline marks the tender as closed by its bid deadline, so
+// that its bids can still be decided on, or clears the mark.
+func (s *Storage) setClosedByDeadline(ctx context.Context, tenderId uuid.UUID, closed bool) error {
+	const op = "storage.postgres.setClosedByDeadline"
+
+	stmt, err := s.prepare(ctx, "UPDATE organization_responsible_tender SET closed_by_deadline = $2 WHERE id = $1")
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, tenderId, closed)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// checkTenderDecidable refuses decisions on bids of a tender that is
+// neither published nor closed by its bid deadline with
+// storage.ErrTenderNotPublished.
+func (s *Storage) checkTenderDecidable(ctx context.Context, tenderId uuid.UUID) error {
+	const op = "storage.postgres.checkTenderDecidable"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT s.status_type = 'PUBLISHED' OR s.status_type = 'CLOSED' AND r.closed_by_deadline
+		FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
+		JOIN status AS s ON t.status_id = s.id
+		WHERE r.id = $1
+	`)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	var decidable bool
+
+	err = stmt.QueryRowContext(ctx, tenderId).Scan(&decidable)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrTenderNotFound
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if !decidable {
+		return storage.ErrTenderNotPublished
+	}
+
+	return nil
+}
+
+// checkBidDeadline refuses changes to bids of a tender past its bid
+// deadline with storage.ErrBidDeadlinePassed.
+func (s *Storage) checkBidDeadline(ctx context.Context, tenderId uuid.UUID) error {
+	t, err := s.GetTender(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+
+	if !t.AcceptsBids(time.Now()) {
+		return storage.ErrBidDeadlinePassed
+	}
+
+	return nil
+}
+
+// deadline passes the bid deadline of t, NULL when it has none.
+func deadline(t internal.Tender) any {
+	if t.BidDeadline == nil {
+		return nil
+	}
+
+	return *t.BidDeadline
+}
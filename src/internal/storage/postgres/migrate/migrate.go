@@ -0,0 +1,284 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed sql/*.sql
+var sqlFS embed.FS
+
+// lockKey identifies the advisory lock held while migrations run,
+// so that replicas starting at the same time apply them one by one.
+const lockKey = 4829105731
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var ErrNoMigrations = errors.New("no applied migrations")
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Load returns the embedded migrations ordered by version.
+func Load() ([]Migration, error) {
+	const op = "storage.postgres.migrate.Load"
+
+	entries, err := fs.ReadDir(sqlFS, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, e := range entries {
+		parts := fileName.FindStringSubmatch(e.Name())
+		if parts == nil {
+			return nil, fmt.Errorf("%s unexpected file %s", op, e.Name())
+		}
+
+		version, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+
+		body, err := fs.ReadFile(sqlFS, "sql/"+e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = m
+		}
+		if m.Name != parts[2] {
+			return nil, fmt.Errorf("%s migration %d has different names", op, version)
+		}
+
+		if parts[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("%s migration %d must have up and down files", op, m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration and returns the applied ones.
+func Up(db *sql.DB) ([]Migration, error) {
+	const op = "storage.postgres.migrate.Up"
+
+	migrations, err := Load()
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	applied := make([]Migration, 0)
+
+	err = withLock(db, func(conn *sql.Conn) error {
+		done, err := appliedAt(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			if _, ok := done[m.Version]; ok {
+				continue
+			}
+
+			err = run(conn, m.Up, "INSERT INTO schema_migrations(version, name) VALUES ($1, $2)", m.Version, m.Name)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+			}
+
+			applied = append(applied, m)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("%s %w", op, err)
+	}
+
+	return applied, nil
+}
+
+// Down reverts the latest applied migration and returns it.
+func Down(db *sql.DB) (Migration, error) {
+	const op = "storage.postgres.migrate.Down"
+
+	migrations, err := Load()
+	if err != nil {
+		return Migration{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	var reverted Migration
+
+	err = withLock(db, func(conn *sql.Conn) error {
+		done, err := appliedAt(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if _, ok := done[m.Version]; !ok {
+				continue
+			}
+
+			err = run(conn, m.Down, "DELETE FROM schema_migrations WHERE version = $1 AND name = $2", m.Version, m.Name)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+			}
+
+			reverted = m
+
+			return nil
+		}
+
+		return ErrNoMigrations
+	})
+	if err != nil {
+		return Migration{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return reverted, nil
+}
+
+// GetStatus lists every known migration with the time it was applied at.
+// AppliedAt is nil for pending migrations.
+func GetStatus(db *sql.DB) ([]Status, error) {
+	const op = "storage.postgres.migrate.GetStatus"
+
+	migrations, err := Load()
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+
+	err = withLock(db, func(conn *sql.Conn) error {
+		done, err := appliedAt(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			st := Status{Version: m.Version, Name: m.Name}
+			if at, ok := done[m.Version]; ok {
+				st.AppliedAt = &at
+			}
+			statuses = append(statuses, st)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return statuses, nil
+}
+
+// withLock runs fn on a single connection holding the migrations advisory lock.
+func withLock(db *sql.DB, fn func(conn *sql.Conn) error) error {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey)
+	if err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey)
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations(
+		    version INT PRIMARY KEY,
+		    name VARCHAR(100) NOT NULL,
+		    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func appliedAt(conn *sql.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(context.Background(), "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := make(map[int]time.Time)
+
+	for rows.Next() {
+		var version int
+		var at time.Time
+		err = rows.Scan(&version, &at)
+		if err != nil {
+			return nil, err
+		}
+		done[version] = at
+	}
+
+	return done, rows.Err()
+}
+
+// run executes a migration script together with its bookkeeping statement
+// in one transaction.
+func run(conn *sql.Conn, script, bookkeeping string, version int, name string) error {
+	ctx := context.Background()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, script)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, bookkeeping, version, name)
+	}
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
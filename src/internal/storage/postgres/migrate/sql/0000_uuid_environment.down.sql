@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bid;
+DROP TABLE IF EXISTS organization_responsible_tender;
+DROP TABLE IF EXISTS tender;
+DROP TABLE IF EXISTS status;
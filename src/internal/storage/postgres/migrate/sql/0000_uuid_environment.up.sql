@@ -0,0 +1,38 @@
+-- 0001 references the employee/organization schema with integer columns,
+-- which can not be created where that schema is keyed by UUIDs (see
+-- задание/README.md). The tables are created here first without those
+-- references, so that 0001 keeps them; 0004 turns the columns into UUIDs
+-- and adds the references. On databases migrated already this is a no-op.
+
+CREATE TABLE IF NOT EXISTS status(
+    id INT PRIMARY KEY,
+    status_type VARCHAR(20)
+);
+
+CREATE TABLE IF NOT EXISTS tender(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    service_type VARCHAR(50),
+    status_id INT REFERENCES status(id) ON DELETE CASCADE,
+    organization_id INT,
+    creator_username VARCHAR(50) NOT NULL,
+    version INT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS organization_responsible_tender(
+    id SERIAL PRIMARY KEY,
+    org_resp_id INT,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS bid(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    status_id INT REFERENCES status(id) ON DELETE CASCADE,
+    tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
+    organization_id INT,
+    creator_username VARCHAR(50) NOT NULL,
+    version INT NOT NULL
+);
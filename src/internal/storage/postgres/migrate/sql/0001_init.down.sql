@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS bid_versions;
+DROP TABLE IF EXISTS tender_versions;
+DROP TABLE IF EXISTS tender_bid;
+DROP TABLE IF EXISTS bid;
+DROP TABLE IF EXISTS organization_responsible_tender;
+DROP TABLE IF EXISTS tender;
+DROP TABLE IF EXISTS status;
@@ -0,0 +1,59 @@
+CREATE TABLE IF NOT EXISTS status(
+    id INT PRIMARY KEY,
+    status_type VARCHAR(20)
+);
+
+INSERT INTO status(id, status_type) VALUES
+    (1, 'CREATED'),
+    (2, 'PUBLISHED'),
+    (3, 'CLOSED'),
+    (4, 'CANCELED')
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS tender(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    service_type VARCHAR(50),
+    status_id INT REFERENCES status(id) ON DELETE CASCADE,
+    organization_id INT REFERENCES organization(id) ON DELETE CASCADE,
+    creator_username VARCHAR(50) NOT NULL,
+    version INT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS organization_responsible_tender(
+    id SERIAL PRIMARY KEY,
+    org_resp_id INT REFERENCES organization_responsible(id) ON DELETE CASCADE,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS bid(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    status_id INT REFERENCES status(id) ON DELETE CASCADE,
+    tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
+    organization_id INT REFERENCES organization(id) ON DELETE CASCADE,
+    creator_username VARCHAR(50) NOT NULL,
+    version INT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tender_bid(
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
+    bid_id INT REFERENCES bid(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS tender_versions(
+    id SERIAL PRIMARY KEY,
+    org_resp_tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE,
+    tender_version INT
+);
+
+CREATE TABLE IF NOT EXISTS bid_versions(
+    id SERIAL PRIMARY KEY,
+    tender_bid_id INT REFERENCES tender_bid(id) ON DELETE CASCADE,
+    bid_id INT REFERENCES bid(id) ON DELETE CASCADE,
+    bid_version INT
+);
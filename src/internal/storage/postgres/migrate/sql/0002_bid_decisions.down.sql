@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bid_decisions;
+
+-- APPROVED and REJECTED statuses are kept: deleting them would cascade to bids.
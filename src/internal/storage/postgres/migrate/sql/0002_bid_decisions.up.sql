@@ -0,0 +1,12 @@
+INSERT INTO status(id, status_type) VALUES
+    (5, 'APPROVED'),
+    (6, 'REJECTED')
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS bid_decisions(
+    id SERIAL PRIMARY KEY,
+    tender_bid_id INT REFERENCES tender_bid(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    UNIQUE (tender_bid_id, username)
+);
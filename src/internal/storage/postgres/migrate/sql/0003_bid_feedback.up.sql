@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS bid_feedback(
+    id SERIAL PRIMARY KEY,
+    tender_bid_id INT REFERENCES tender_bid(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL,
+    description VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
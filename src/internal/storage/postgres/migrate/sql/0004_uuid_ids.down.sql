@@ -0,0 +1,74 @@
+-- Tenders and bids get new integer ids, every reference to them is rewritten.
+-- Organization ids are mapped back from 00000000-0000-0000-0000-<hex id>,
+-- so only ids produced by the up migration round-trip. Employee and
+-- organization tables the up migration converted from integers keep UUIDs.
+
+ALTER TABLE bid DROP CONSTRAINT IF EXISTS bid_tender_id_fkey;
+ALTER TABLE bid DROP CONSTRAINT IF EXISTS bid_organization_id_fkey;
+ALTER TABLE tender DROP CONSTRAINT IF EXISTS tender_organization_id_fkey;
+ALTER TABLE tender_bid DROP CONSTRAINT IF EXISTS tender_bid_tender_id_fkey;
+ALTER TABLE tender_versions DROP CONSTRAINT IF EXISTS tender_versions_org_resp_tender_id_fkey;
+ALTER TABLE bid_versions DROP CONSTRAINT IF EXISTS bid_versions_tender_bid_id_fkey;
+ALTER TABLE bid_decisions DROP CONSTRAINT IF EXISTS bid_decisions_tender_bid_id_fkey;
+ALTER TABLE bid_feedback DROP CONSTRAINT IF EXISTS bid_feedback_tender_bid_id_fkey;
+ALTER TABLE organization_responsible_tender DROP CONSTRAINT IF EXISTS organization_responsible_tender_org_resp_id_fkey;
+
+-- organizations
+ALTER TABLE tender ALTER COLUMN organization_id TYPE INT USING ('x' || right(replace(organization_id::text, '-', ''), 8))::bit(32)::int;
+ALTER TABLE bid ALTER COLUMN organization_id TYPE INT USING ('x' || right(replace(organization_id::text, '-', ''), 8))::bit(32)::int;
+ALTER TABLE organization_responsible_tender ALTER COLUMN org_resp_id TYPE INT USING ('x' || right(replace(org_resp_id::text, '-', ''), 8))::bit(32)::int;
+
+-- bids
+ALTER TABLE tender_bid ADD COLUMN num SERIAL;
+
+ALTER TABLE bid_versions ADD COLUMN tender_bid_num INT;
+UPDATE bid_versions SET tender_bid_num = t.num FROM tender_bid AS t WHERE bid_versions.tender_bid_id = t.id;
+ALTER TABLE bid_versions DROP COLUMN tender_bid_id;
+ALTER TABLE bid_versions RENAME COLUMN tender_bid_num TO tender_bid_id;
+
+ALTER TABLE bid_decisions DROP CONSTRAINT IF EXISTS bid_decisions_tender_bid_id_username_key;
+ALTER TABLE bid_decisions ADD COLUMN tender_bid_num INT;
+UPDATE bid_decisions SET tender_bid_num = t.num FROM tender_bid AS t WHERE bid_decisions.tender_bid_id = t.id;
+ALTER TABLE bid_decisions DROP COLUMN tender_bid_id;
+ALTER TABLE bid_decisions RENAME COLUMN tender_bid_num TO tender_bid_id;
+ALTER TABLE bid_decisions ADD UNIQUE (tender_bid_id, username);
+
+ALTER TABLE bid_feedback ADD COLUMN tender_bid_num INT;
+UPDATE bid_feedback SET tender_bid_num = t.num FROM tender_bid AS t WHERE bid_feedback.tender_bid_id = t.id;
+ALTER TABLE bid_feedback DROP COLUMN tender_bid_id;
+ALTER TABLE bid_feedback RENAME COLUMN tender_bid_num TO tender_bid_id;
+
+ALTER TABLE tender_bid DROP CONSTRAINT tender_bid_pkey;
+ALTER TABLE tender_bid DROP COLUMN id;
+ALTER TABLE tender_bid RENAME COLUMN num TO id;
+ALTER TABLE tender_bid ADD PRIMARY KEY (id);
+
+-- tenders
+ALTER TABLE organization_responsible_tender ADD COLUMN num SERIAL;
+
+ALTER TABLE bid ADD COLUMN tender_num INT;
+UPDATE bid SET tender_num = r.num FROM organization_responsible_tender AS r WHERE bid.tender_id = r.id;
+ALTER TABLE bid DROP COLUMN tender_id;
+ALTER TABLE bid RENAME COLUMN tender_num TO tender_id;
+
+ALTER TABLE tender_bid ADD COLUMN tender_num INT;
+UPDATE tender_bid SET tender_num = r.num FROM organization_responsible_tender AS r WHERE tender_bid.tender_id = r.id;
+ALTER TABLE tender_bid DROP COLUMN tender_id;
+ALTER TABLE tender_bid RENAME COLUMN tender_num TO tender_id;
+
+ALTER TABLE tender_versions ADD COLUMN org_resp_tender_num INT;
+UPDATE tender_versions SET org_resp_tender_num = r.num FROM organization_responsible_tender AS r WHERE tender_versions.org_resp_tender_id = r.id;
+ALTER TABLE tender_versions DROP COLUMN org_resp_tender_id;
+ALTER TABLE tender_versions RENAME COLUMN org_resp_tender_num TO org_resp_tender_id;
+
+ALTER TABLE organization_responsible_tender DROP CONSTRAINT organization_responsible_tender_pkey;
+ALTER TABLE organization_responsible_tender DROP COLUMN id;
+ALTER TABLE organization_responsible_tender RENAME COLUMN num TO id;
+ALTER TABLE organization_responsible_tender ADD PRIMARY KEY (id);
+
+ALTER TABLE bid ADD FOREIGN KEY (tender_id) REFERENCES organization_responsible_tender(id) ON DELETE CASCADE;
+ALTER TABLE tender_bid ADD FOREIGN KEY (tender_id) REFERENCES organization_responsible_tender(id) ON DELETE CASCADE;
+ALTER TABLE tender_versions ADD FOREIGN KEY (org_resp_tender_id) REFERENCES organization_responsible_tender(id) ON DELETE CASCADE;
+ALTER TABLE bid_versions ADD FOREIGN KEY (tender_bid_id) REFERENCES tender_bid(id) ON DELETE CASCADE;
+ALTER TABLE bid_decisions ADD FOREIGN KEY (tender_bid_id) REFERENCES tender_bid(id) ON DELETE CASCADE;
+ALTER TABLE bid_feedback ADD FOREIGN KEY (tender_bid_id) REFERENCES tender_bid(id) ON DELETE CASCADE;
@@ -0,0 +1,134 @@
+-- Tender, bid and organization identifiers become UUIDs to match the
+-- employee/organization schema. Existing tenders and bids get random UUIDs,
+-- every reference to them is rewritten accordingly.
+-- Integer organization ids are mapped to 00000000-0000-0000-0000-<hex id>.
+-- Employee and organization tables still keyed by integers, as the service
+-- first expected them, are converted the same way so references keep
+-- pointing at the same rows. Organizations missing under the mapped id are
+-- created, responsibles that are missing are cleared as if they had been
+-- removed.
+
+ALTER TABLE bid DROP CONSTRAINT IF EXISTS bid_tender_id_fkey;
+ALTER TABLE bid DROP CONSTRAINT IF EXISTS bid_organization_id_fkey;
+ALTER TABLE tender DROP CONSTRAINT IF EXISTS tender_organization_id_fkey;
+ALTER TABLE tender_bid DROP CONSTRAINT IF EXISTS tender_bid_tender_id_fkey;
+ALTER TABLE tender_versions DROP CONSTRAINT IF EXISTS tender_versions_org_resp_tender_id_fkey;
+ALTER TABLE bid_versions DROP CONSTRAINT IF EXISTS bid_versions_tender_bid_id_fkey;
+ALTER TABLE bid_decisions DROP CONSTRAINT IF EXISTS bid_decisions_tender_bid_id_fkey;
+ALTER TABLE bid_feedback DROP CONSTRAINT IF EXISTS bid_feedback_tender_bid_id_fkey;
+ALTER TABLE organization_responsible_tender DROP CONSTRAINT IF EXISTS organization_responsible_tender_org_resp_id_fkey;
+
+-- tenders
+ALTER TABLE organization_responsible_tender ADD COLUMN uid UUID NOT NULL DEFAULT gen_random_uuid();
+
+ALTER TABLE bid ADD COLUMN tender_uid UUID;
+UPDATE bid SET tender_uid = r.uid FROM organization_responsible_tender AS r WHERE bid.tender_id = r.id;
+ALTER TABLE bid DROP COLUMN tender_id;
+ALTER TABLE bid RENAME COLUMN tender_uid TO tender_id;
+
+ALTER TABLE tender_bid ADD COLUMN tender_uid UUID;
+UPDATE tender_bid SET tender_uid = r.uid FROM organization_responsible_tender AS r WHERE tender_bid.tender_id = r.id;
+ALTER TABLE tender_bid DROP COLUMN tender_id;
+ALTER TABLE tender_bid RENAME COLUMN tender_uid TO tender_id;
+
+ALTER TABLE tender_versions ADD COLUMN org_resp_tender_uid UUID;
+UPDATE tender_versions SET org_resp_tender_uid = r.uid FROM organization_responsible_tender AS r WHERE tender_versions.org_resp_tender_id = r.id;
+ALTER TABLE tender_versions DROP COLUMN org_resp_tender_id;
+ALTER TABLE tender_versions RENAME COLUMN org_resp_tender_uid TO org_resp_tender_id;
+
+ALTER TABLE organization_responsible_tender DROP CONSTRAINT organization_responsible_tender_pkey;
+ALTER TABLE organization_responsible_tender DROP COLUMN id;
+ALTER TABLE organization_responsible_tender RENAME COLUMN uid TO id;
+ALTER TABLE organization_responsible_tender ADD PRIMARY KEY (id);
+
+-- bids
+ALTER TABLE tender_bid ADD COLUMN uid UUID NOT NULL DEFAULT gen_random_uuid();
+
+ALTER TABLE bid_versions ADD COLUMN tender_bid_uid UUID;
+UPDATE bid_versions SET tender_bid_uid = t.uid FROM tender_bid AS t WHERE bid_versions.tender_bid_id = t.id;
+ALTER TABLE bid_versions DROP COLUMN tender_bid_id;
+ALTER TABLE bid_versions RENAME COLUMN tender_bid_uid TO tender_bid_id;
+
+ALTER TABLE bid_decisions DROP CONSTRAINT IF EXISTS bid_decisions_tender_bid_id_username_key;
+ALTER TABLE bid_decisions ADD COLUMN tender_bid_uid UUID;
+UPDATE bid_decisions SET tender_bid_uid = t.uid FROM tender_bid AS t WHERE bid_decisions.tender_bid_id = t.id;
+ALTER TABLE bid_decisions DROP COLUMN tender_bid_id;
+ALTER TABLE bid_decisions RENAME COLUMN tender_bid_uid TO tender_bid_id;
+ALTER TABLE bid_decisions ADD UNIQUE (tender_bid_id, username);
+
+ALTER TABLE bid_feedback ADD COLUMN tender_bid_uid UUID;
+UPDATE bid_feedback SET tender_bid_uid = t.uid FROM tender_bid AS t WHERE bid_feedback.tender_bid_id = t.id;
+ALTER TABLE bid_feedback DROP COLUMN tender_bid_id;
+ALTER TABLE bid_feedback RENAME COLUMN tender_bid_uid TO tender_bid_id;
+
+ALTER TABLE tender_bid DROP CONSTRAINT tender_bid_pkey;
+ALTER TABLE tender_bid DROP COLUMN id;
+ALTER TABLE tender_bid RENAME COLUMN uid TO id;
+ALTER TABLE tender_bid ADD PRIMARY KEY (id);
+
+-- organizations
+DO $$
+DECLARE
+    c RECORD;
+    fk RECORD;
+    converted BOOLEAN := FALSE;
+BEGIN
+    FOR c IN
+        SELECT table_name, column_name
+        FROM information_schema.columns
+        WHERE table_schema = current_schema()
+          AND data_type IN ('smallint', 'integer', 'bigint')
+          AND (table_name, column_name) IN (
+              ('employee', 'id'),
+              ('organization', 'id'),
+              ('organization_responsible', 'id'),
+              ('organization_responsible', 'organization_id'),
+              ('organization_responsible', 'user_id'))
+    LOOP
+        IF NOT converted THEN
+            FOR fk IN
+                SELECT conname FROM pg_constraint
+                WHERE conrelid = 'organization_responsible'::regclass AND contype = 'f'
+            LOOP
+                EXECUTE format('ALTER TABLE organization_responsible DROP CONSTRAINT %I', fk.conname);
+            END LOOP;
+            converted := TRUE;
+        END IF;
+
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN %I DROP DEFAULT', c.table_name, c.column_name);
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN %I TYPE UUID USING lpad(to_hex(%I), 32, ''0'')::uuid',
+            c.table_name, c.column_name, c.column_name);
+        IF c.column_name = 'id' THEN
+            EXECUTE format('ALTER TABLE %I ALTER COLUMN id SET DEFAULT gen_random_uuid()', c.table_name);
+        END IF;
+    END LOOP;
+
+    IF converted THEN
+        ALTER TABLE organization_responsible ADD FOREIGN KEY (organization_id) REFERENCES organization(id) ON DELETE CASCADE;
+        ALTER TABLE organization_responsible ADD FOREIGN KEY (user_id) REFERENCES employee(id) ON DELETE CASCADE;
+    END IF;
+END $$;
+
+ALTER TABLE tender ALTER COLUMN organization_id TYPE UUID USING lpad(to_hex(organization_id), 32, '0')::uuid;
+ALTER TABLE bid ALTER COLUMN organization_id TYPE UUID USING lpad(to_hex(organization_id), 32, '0')::uuid;
+ALTER TABLE organization_responsible_tender ALTER COLUMN org_resp_id TYPE UUID USING lpad(to_hex(org_resp_id), 32, '0')::uuid;
+
+INSERT INTO organization(id, name)
+SELECT o.id, 'Organization ' || o.id
+FROM (SELECT organization_id AS id FROM tender UNION SELECT organization_id FROM bid) AS o
+WHERE o.id IS NOT NULL
+ON CONFLICT (id) DO NOTHING;
+
+UPDATE organization_responsible_tender SET org_resp_id = NULL
+WHERE org_resp_id NOT IN (SELECT id FROM organization_responsible);
+
+ALTER TABLE tender ADD FOREIGN KEY (organization_id) REFERENCES organization(id) ON DELETE CASCADE;
+ALTER TABLE bid ADD FOREIGN KEY (organization_id) REFERENCES organization(id) ON DELETE CASCADE;
+ALTER TABLE organization_responsible_tender ADD FOREIGN KEY (org_resp_id) REFERENCES organization_responsible(id) ON DELETE CASCADE;
+
+ALTER TABLE bid ADD FOREIGN KEY (tender_id) REFERENCES organization_responsible_tender(id) ON DELETE CASCADE;
+ALTER TABLE tender_bid ADD FOREIGN KEY (tender_id) REFERENCES organization_responsible_tender(id) ON DELETE CASCADE;
+ALTER TABLE tender_versions ADD FOREIGN KEY (org_resp_tender_id) REFERENCES organization_responsible_tender(id) ON DELETE CASCADE;
+ALTER TABLE bid_versions ADD FOREIGN KEY (tender_bid_id) REFERENCES tender_bid(id) ON DELETE CASCADE;
+ALTER TABLE bid_decisions ADD FOREIGN KEY (tender_bid_id) REFERENCES tender_bid(id) ON DELETE CASCADE;
+ALTER TABLE bid_feedback ADD FOREIGN KEY (tender_bid_id) REFERENCES tender_bid(id) ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+ALTER TABLE organization_responsible_tender DROP CONSTRAINT IF EXISTS organization_responsible_tender_org_resp_id_fkey;
+ALTER TABLE organization_responsible_tender ADD FOREIGN KEY (org_resp_id) REFERENCES organization_responsible(id) ON DELETE CASCADE;
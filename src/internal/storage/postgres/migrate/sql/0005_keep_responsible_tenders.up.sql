@@ -0,0 +1,6 @@
+-- Responsibles and employees can now be removed through the API. Tenders
+-- outlive the responsible who created them, so the reference is cleared
+-- instead of deleting the tender with every bid on it.
+
+ALTER TABLE organization_responsible_tender DROP CONSTRAINT IF EXISTS organization_responsible_tender_org_resp_id_fkey;
+ALTER TABLE organization_responsible_tender ADD FOREIGN KEY (org_resp_id) REFERENCES organization_responsible(id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS author;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS created_at;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS author;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,16 @@
+-- Every version records who made it and when. Versions made before this
+-- migration are dated by it, and only first versions get an author:
+-- the employee who created the tender or bid.
+
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS author VARCHAR(50);
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS author VARCHAR(50);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE tender_versions AS v SET author = t.creator_username
+FROM tender AS t
+WHERE t.id = v.tender_id AND v.tender_version = 1;
+
+UPDATE bid_versions AS v SET author = b.creator_username
+FROM bid AS b
+WHERE b.id = v.bid_id AND v.bid_version = 1;
@@ -0,0 +1,6 @@
+ALTER TABLE organization_responsible_tender DROP COLUMN IF EXISTS created_at;
+ALTER TABLE organization_responsible_tender DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE organization_responsible_tender DROP COLUMN IF EXISTS last_edited_by;
+ALTER TABLE tender_bid DROP COLUMN IF EXISTS created_at;
+ALTER TABLE tender_bid DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE tender_bid DROP COLUMN IF EXISTS last_edited_by;
@@ -0,0 +1,28 @@
+-- Tenders and bids know when they were created, when they last changed
+-- and who changed them. Existing rows take both times from their version
+-- history and the editor from the author of the latest version.
+
+ALTER TABLE organization_responsible_tender ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE organization_responsible_tender ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE organization_responsible_tender ADD COLUMN IF NOT EXISTS last_edited_by VARCHAR(50);
+ALTER TABLE tender_bid ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE tender_bid ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE tender_bid ADD COLUMN IF NOT EXISTS last_edited_by VARCHAR(50);
+
+UPDATE organization_responsible_tender AS r SET created_at = v.created_at
+FROM tender_versions AS v
+WHERE v.org_resp_tender_id = r.id AND v.tender_version = 1;
+
+UPDATE organization_responsible_tender AS r SET updated_at = v.created_at, last_edited_by = v.author
+FROM tender_versions AS v
+WHERE v.org_resp_tender_id = r.id
+  AND v.tender_version = (SELECT MAX(tender_version) FROM tender_versions WHERE org_resp_tender_id = r.id);
+
+UPDATE tender_bid AS t SET created_at = v.created_at
+FROM bid_versions AS v
+WHERE v.tender_bid_id = t.id AND v.bid_version = 1;
+
+UPDATE tender_bid AS t SET updated_at = v.created_at, last_edited_by = v.author
+FROM bid_versions AS v
+WHERE v.tender_bid_id = t.id
+  AND v.bid_version = (SELECT MAX(bid_version) FROM bid_versions WHERE tender_bid_id = t.id);
@@ -0,0 +1,29 @@
+-- Every change of a tender or bid is recorded in the same transaction as
+-- the change. Events are never updated or deleted, and outlive the
+-- entities and organizations they mention, so nothing references them.
+
+CREATE TABLE IF NOT EXISTS audit_event (
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    organization_id UUID NOT NULL,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_event_organization_id_created_at_idx ON audit_event(organization_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_event_entity_id_idx ON audit_event(entity_id);
+
+CREATE OR REPLACE FUNCTION audit_event_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_event is append-only';
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_event_append_only ON audit_event;
+CREATE TRIGGER audit_event_append_only BEFORE UPDATE OR DELETE ON audit_event
+    FOR EACH ROW EXECUTE FUNCTION audit_event_append_only();
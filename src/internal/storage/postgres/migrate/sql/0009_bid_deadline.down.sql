@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tender_bid_deadline_idx;
+
+ALTER TABLE tender DROP COLUMN IF EXISTS bid_deadline;
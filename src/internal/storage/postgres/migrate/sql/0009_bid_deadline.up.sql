@@ -0,0 +1,6 @@
+-- Tenders may stop taking bids at a deadline. It is part of the tender
+-- version, so edits keep it and rollbacks restore it. Times are UTC.
+
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS bid_deadline TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS tender_bid_deadline_idx ON tender(bid_deadline) WHERE bid_deadline IS NOT NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE organization_responsible_tender ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE organization_responsible_tender ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE tender_bid ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE tender_bid ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE tender_versions ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE bid_versions ALTER COLUMN created_at TYPE TIMESTAMP;
@@ -0,0 +1,10 @@
+-- Creation and update times of tenders, bids and their versions are kept
+-- with their time zone. Times written so far are CURRENT_TIMESTAMP in the
+-- session time zone, which is how the conversion reads them.
+
+ALTER TABLE organization_responsible_tender ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE organization_responsible_tender ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE tender_bid ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE tender_bid ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE tender_versions ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE bid_versions ALTER COLUMN created_at TYPE TIMESTAMPTZ;
@@ -0,0 +1 @@
+ALTER TABLE audit_event ALTER COLUMN created_at TYPE TIMESTAMP;
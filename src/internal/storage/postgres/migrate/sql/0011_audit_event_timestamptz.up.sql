@@ -0,0 +1,5 @@
+-- Audit events are filtered by instants given with a time zone, so they
+-- are kept with one. Events recorded so far are CURRENT_TIMESTAMP in the
+-- session time zone, which is how the conversion reads them.
+
+ALTER TABLE audit_event ALTER COLUMN created_at TYPE TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+-- Events can not be deleted, longer entity types are cut to fit.
+
+ALTER TABLE audit_event ALTER COLUMN entity_type TYPE VARCHAR(20) USING left(entity_type, 20);
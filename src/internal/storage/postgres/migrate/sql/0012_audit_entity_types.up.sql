@@ -0,0 +1,4 @@
+-- Changes of employees, organizations and responsibles are audited too,
+-- organization_responsible does not fit the entity type column.
+
+ALTER TABLE audit_event ALTER COLUMN entity_type TYPE VARCHAR(30);
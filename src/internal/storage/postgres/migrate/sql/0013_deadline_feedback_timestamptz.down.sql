@@ -0,0 +1,2 @@
+ALTER TABLE tender ALTER COLUMN bid_deadline TYPE TIMESTAMP USING bid_deadline AT TIME ZONE 'UTC';
+ALTER TABLE bid_feedback ALTER COLUMN created_at TYPE TIMESTAMP;
@@ -0,0 +1,6 @@
+-- Bid deadlines and feedback times are kept with their time zone, like the
+-- other instants. Deadlines so far were written in UTC, feedback times are
+-- CURRENT_TIMESTAMP in the session time zone.
+
+ALTER TABLE tender ALTER COLUMN bid_deadline TYPE TIMESTAMPTZ USING bid_deadline AT TIME ZONE 'UTC';
+ALTER TABLE bid_feedback ALTER COLUMN created_at TYPE TIMESTAMPTZ;
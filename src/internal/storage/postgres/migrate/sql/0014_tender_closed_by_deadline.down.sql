@@ -0,0 +1 @@
+ALTER TABLE organization_responsible_tender DROP COLUMN IF EXISTS closed_by_deadline;
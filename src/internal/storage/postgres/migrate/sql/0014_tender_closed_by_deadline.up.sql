@@ -0,0 +1,4 @@
+-- Tenders closed because their bid deadline passed still take decisions on
+-- their bids until one is approved.
+
+ALTER TABLE organization_responsible_tender ADD COLUMN IF NOT EXISTS closed_by_deadline BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,228 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/config"
+	"tender-app-backend/src/internal/storage/postgres"
+	"tender-app-backend/src/internal/storage/postgres/migrate"
+	"testing"
+)
+
+// preUUID is what the service kept before 0004: integer ids everywhere,
+// referencing organization 1 and 2 and responsible 7.
+const preUUID = `
+	INSERT INTO tender(id, name, description, service_type, status_id, organization_id, creator_username, version)
+	VALUES (1, 'Roads', 'Roads description', 'Construction', 2, 1, 'alice', 1);
+	INSERT INTO organization_responsible_tender(id, org_resp_id, tender_id) VALUES (1, 7, 1);
+	INSERT INTO tender_versions(org_resp_tender_id, tender_id, tender_version) VALUES (1, 1, 1);
+
+	INSERT INTO bid(id, name, description, status_id, tender_id, organization_id, creator_username, version)
+	VALUES (1, 'Asphalt', 'Asphalt description', 2, 1, 2, 'carol', 1);
+	INSERT INTO tender_bid(id, tender_id, bid_id) VALUES (1, 1, 1);
+	INSERT INTO bid_versions(tender_bid_id, bid_id, bid_version) VALUES (1, 1, 1);
+	INSERT INTO bid_decisions(tender_bid_id, username, decision) VALUES (1, 'bob', 'Approved');
+	INSERT INTO bid_feedback(tender_bid_id, username, description) VALUES (1, 'bob', 'Fast');
+`
+
+// baselineRows fills the integer keyed employee and organization tables
+// of testdata/baseline.sql with the rows preUUID references.
+const baselineRows = `
+	INSERT INTO employee(id, username) VALUES (1, 'alice'), (2, 'carol');
+	INSERT INTO organization(id, name) VALUES (1, 'Roads Inc'), (2, 'Asphalt LLC');
+	INSERT INTO organization_responsible(id, organization_id, user_id) VALUES (7, 1, 1);
+`
+
+func TestMigratePreUUID(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		rows   string
+		// orgNames are the names of organization 1 and 2 after the
+		// migration, resp is the responsible of the tender.
+		orgNames [2]string
+		resp     uuid.NullUUID
+	}{
+		{
+			name:   "uuid environment",
+			schema: "schema.sql",
+			orgNames: [2]string{
+				"Organization 00000000-0000-0000-0000-000000000001",
+				"Organization 00000000-0000-0000-0000-000000000002",
+			},
+		},
+		{
+			name:     "integer environment",
+			schema:   "baseline.sql",
+			rows:     baselineRows,
+			orgNames: [2]string{"Roads Inc", "Asphalt LLC"},
+			resp:     uuid.NullUUID{UUID: uuid.MustParse("00000000-0000-0000-0000-000000000007"), Valid: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			needPostgres(t)
+
+			conn := inSchema(t, "pre_uuid")
+
+			db, err := sql.Open("postgres", conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { db.Close() })
+
+			schema, err := os.ReadFile(filepath.Join("testdata", tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = db.Exec(string(schema)); err != nil {
+				t.Fatalf("create employee and organization tables: %v", err)
+			}
+			if tt.rows != "" {
+				if _, err = db.Exec(tt.rows); err != nil {
+					t.Fatalf("seed employees and organizations: %v", err)
+				}
+			}
+
+			// GetStatus creates the bookkeeping table, the first migrations
+			// are applied by hand to stop before 0004.
+			if _, err = migrate.GetStatus(db); err != nil {
+				t.Fatal(err)
+			}
+
+			migrations, err := migrate.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range migrations {
+				if m.Version > 3 {
+					break
+				}
+				if _, err = db.Exec(m.Up); err != nil {
+					t.Fatalf("migration %d_%s: %v", m.Version, m.Name, err)
+				}
+				if _, err = db.Exec("INSERT INTO schema_migrations(version, name) VALUES ($1, $2)", m.Version, m.Name); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err = db.Exec(preUUID); err != nil {
+				t.Fatalf("seed: %v", err)
+			}
+
+			s, err := postgres.New(&config.Config{Postgres: config.Postgres{ConnURL: conn}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { s.Close() })
+
+			var tenderId, bidId uuid.UUID
+			if err = db.QueryRow("SELECT id FROM organization_responsible_tender").Scan(&tenderId); err != nil {
+				t.Fatal(err)
+			}
+			if err = db.QueryRow("SELECT id FROM tender_bid").Scan(&bidId); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+
+			tender, err := s.GetTender(ctx, tenderId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := uuid.MustParse("00000000-0000-0000-0000-000000000001"); tender.OrganizationId != want {
+				t.Fatalf("got tender organization %s, want %s", tender.OrganizationId, want)
+			}
+			if tender.Status != internal.TenderPublished || tender.LastEditedBy != "alice" {
+				t.Fatalf("got tender %+v", tender)
+			}
+
+			bid, err := s.GetBid(ctx, bidId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := uuid.MustParse("00000000-0000-0000-0000-000000000002"); bid.OrganizationId != want || bid.TenderId != tenderId {
+				t.Fatalf("got bid organization %s and tender %s, want %s and %s", bid.OrganizationId, bid.TenderId, want, tenderId)
+			}
+
+			for i, orgId := range []uuid.UUID{tender.OrganizationId, bid.OrganizationId} {
+				org, err := s.GetOrganization(ctx, orgId)
+				if err != nil {
+					t.Fatalf("organization %s: %v", orgId, err)
+				}
+				if org.Name != tt.orgNames[i] {
+					t.Fatalf("got organization %s named %q, want %q", orgId, org.Name, tt.orgNames[i])
+				}
+			}
+
+			var resp uuid.NullUUID
+			if err = db.QueryRow("SELECT org_resp_id FROM organization_responsible_tender").Scan(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp != tt.resp {
+				t.Fatalf("got responsible %v, want %v", resp, tt.resp)
+			}
+
+			if tt.resp.Valid {
+				ok, err := s.IsOrganizationResponsible(ctx, tender.OrganizationId, "alice")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !ok {
+					t.Fatal("alice is no longer responsible for the tender organization")
+				}
+			}
+		})
+	}
+}
+
+// inSchema returns connStr with search_path set to a fresh schema, so that
+// migrations can be run from scratch next to the database other tests use.
+func inSchema(t *testing.T, schema string) string {
+	t.Helper()
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err = db.Exec("DROP SCHEMA IF EXISTS " + schema + " CASCADE; CREATE SCHEMA " + schema); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		db, err := sql.Open("postgres", connStr)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer db.Close()
+
+		if _, err = db.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// lib/pq passes settings it does not know to the server.
+	if !strings.HasPrefix(connStr, "postgres://") && !strings.HasPrefix(connStr, "postgresql://") {
+		return connStr + " search_path=" + schema
+	}
+
+	u, err := url.Parse(connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
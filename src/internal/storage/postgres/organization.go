@@ -0,0 +1,693 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+)
+
+// uniqueViolation is the postgres error code for a broken unique constraint.
+const uniqueViolation = "23505"
+
+func (s *Storage) CreateEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+	var created internal.Employee
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		created, err = tx.createEmployee(ctx, e, actor)
+		return err
+	})
+
+	return created, err
+}
+
+func (s *Storage) createEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+	const op = "storage.postgres.CreateEmployee"
+
+	if e.Id == uuid.Nil {
+		e.Id = uuid.New()
+	}
+
+	stmt, err := s.prepare(ctx, `
+		INSERT INTO employee(id, username, first_name, last_name)
+		VALUES ($1, $2, $3, $4)
+	`)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, e.Id, e.Username, e.FirstName, e.LastName)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, uniqueErr(err))
+	}
+
+	err = s.recordEmployeeAudit(ctx, internal.ActionCreate, actor, nil, &e)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return e, nil
+}
+
+func (s *Storage) GetEmployee(ctx context.Context, id uuid.UUID) (internal.Employee, error) {
+	const op = "storage.postgres.GetEmployee"
+
+	e, err := s.employee(ctx, `id = $1`, id)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return e, nil
+}
+
+func (s *Storage) GetEmployeeByUsername(ctx context.Context, username string) (internal.Employee, error) {
+	const op = "storage.postgres.GetEmployeeByUsername"
+
+	e, err := s.employee(ctx, `username = $1`, username)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return e, nil
+}
+
+// GetEmployeesList returns a page of employees ordered by username.
+func (s *Storage) GetEmployeesList(ctx context.Context, page internal.Page) ([]internal.Employee, error) {
+	const op = "storage.postgres.GetEmployeesList"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT id, username, COALESCE(first_name, ''), COALESCE(last_name, '')
+		FROM employee
+		ORDER BY username
+		LIMIT $1 OFFSET $2
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	employees, err := scanEmployees(ctx, stmt, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return employees, nil
+}
+
+// EditEmployee changes the names of the employee e.Id, empty fields keep
+// their values. The username can not change, tenders and bids refer to it.
+func (s *Storage) EditEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+	var edited internal.Employee
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		edited, err = tx.editEmployee(ctx, e, actor)
+		return err
+	})
+
+	return edited, err
+}
+
+func (s *Storage) editEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error) {
+	const op = "storage.postgres.EditEmployee"
+
+	before, err := s.GetEmployee(ctx, e.Id)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		UPDATE employee
+		SET first_name = COALESCE(NULLIF($2, ''), first_name),
+			last_name = COALESCE(NULLIF($3, ''), last_name),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING id, username, COALESCE(first_name, ''), COALESCE(last_name, '')
+	`)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	var edited internal.Employee
+	err = stmt.QueryRowContext(ctx, e.Id, e.FirstName, e.LastName).Scan(&edited.Id, &edited.Username, &edited.FirstName, &edited.LastName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+	}
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordEmployeeAudit(ctx, internal.ActionEdit, actor, &before, &edited)
+	if err != nil {
+		return internal.Employee{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return edited, nil
+}
+
+// DeleteEmployee removes the employee along with their responsibilities.
+// An organization can not be left without a responsible this way.
+func (s *Storage) DeleteEmployee(ctx context.Context, id uuid.UUID, actor string) error {
+	return s.withTx(ctx, func(tx *Storage) error {
+		return tx.deleteEmployee(ctx, id, actor)
+	})
+}
+
+func (s *Storage) deleteEmployee(ctx context.Context, id uuid.UUID, actor string) error {
+	const op = "storage.postgres.DeleteEmployee"
+
+	e, err := s.GetEmployee(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	lockOrgs, err := s.prepare(ctx, `
+		SELECT o.id
+		FROM organization AS o JOIN organization_responsible AS r ON r.organization_id = o.id
+		WHERE r.user_id = $1
+		ORDER BY o.id
+		FOR UPDATE OF o
+	`)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	orgIds, err := scanIds(ctx, lockOrgs, e.Id)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	for _, orgId := range orgIds {
+		n, err := s.responsibleCount(ctx, orgId)
+		if err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+		if n <= 1 {
+			return fmt.Errorf("%s %w", op, storage.ErrLastResponsible)
+		}
+	}
+
+	stmt, err := s.prepare(ctx, `DELETE FROM employee WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, e.Id)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	for _, event := range storage.EmployeeEvents(internal.ActionDelete, actor, orgIds, &e, nil) {
+		err = s.recordAudit(ctx, event)
+		if err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// recordEmployeeAudit records a change of an employee for every
+// organization they are responsible for.
+func (s *Storage) recordEmployeeAudit(ctx context.Context, action, actor string, before, after *internal.Employee) error {
+	id := after.Id
+	if before != nil {
+		id = before.Id
+	}
+
+	stmt, err := s.prepare(ctx, `
+		SELECT organization_id
+		FROM organization_responsible
+		WHERE user_id = $1
+		ORDER BY organization_id
+	`)
+	if err != nil {
+		return err
+	}
+
+	orgIds, err := scanIds(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range storage.EmployeeEvents(action, actor, orgIds, before, after) {
+		err = s.recordAudit(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// employee reads the single employee matching where.
+func (s *Storage) employee(ctx context.Context, where string, arg any) (internal.Employee, error) {
+	stmt, err := s.prepare(ctx, `
+		SELECT id, username, COALESCE(first_name, ''), COALESCE(last_name, '')
+		FROM employee
+		WHERE `+where)
+	if err != nil {
+		return internal.Employee{}, err
+	}
+
+	var e internal.Employee
+	err = stmt.QueryRowContext(ctx, arg).Scan(&e.Id, &e.Username, &e.FirstName, &e.LastName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return internal.Employee{}, storage.ErrUserNotFound
+	}
+	if err != nil {
+		return internal.Employee{}, err
+	}
+
+	return e, nil
+}
+
+func scanEmployees(ctx context.Context, stmt *sql.Stmt, args ...any) ([]internal.Employee, error) {
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := make([]internal.Employee, 0)
+	for rows.Next() {
+		var e internal.Employee
+		if err := rows.Scan(&e.Id, &e.Username, &e.FirstName, &e.LastName); err != nil {
+			return nil, err
+		}
+		employees = append(employees, e)
+	}
+
+	return employees, rows.Err()
+}
+
+func scanIds(ctx context.Context, stmt *sql.Stmt, args ...any) ([]uuid.UUID, error) {
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// CreateOrganization stores o and makes the given employees its responsibles.
+func (s *Storage) CreateOrganization(ctx context.Context, o internal.Organization, actor string, responsibles ...string) (internal.Organization, error) {
+	var created internal.Organization
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		created, err = tx.createOrganization(ctx, o, actor, responsibles)
+		return err
+	})
+
+	return created, err
+}
+
+func (s *Storage) createOrganization(ctx context.Context, o internal.Organization, actor string, responsibles []string) (internal.Organization, error) {
+	const op = "storage.postgres.CreateOrganization"
+
+	if o.Id == uuid.Nil {
+		o.Id = uuid.New()
+	}
+
+	stmt, err := s.prepare(ctx, `
+		INSERT INTO organization(id, name, description, type)
+		VALUES ($1, $2, $3, NULLIF($4, '')::organization_type)
+	`)
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, o.Id, o.Name, o.Description, o.Type)
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, uniqueErr(err))
+	}
+
+	err = s.recordAudit(ctx, storage.OrganizationEvent(internal.ActionCreate, actor, nil, &o))
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	for _, username := range responsibles {
+		err = s.AddOrganizationResponsible(ctx, o.Id, username, actor)
+		if err != nil {
+			return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	return o, nil
+}
+
+// organizationColumns are read by scanOrganization.
+const organizationColumns = `id, name, COALESCE(description, ''), COALESCE(type::text, '')`
+
+func scanOrganization(row interface{ Scan(...any) error }) (internal.Organization, error) {
+	var o internal.Organization
+	err := row.Scan(&o.Id, &o.Name, &o.Description, &o.Type)
+
+	return o, err
+}
+
+func (s *Storage) GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error) {
+	const op = "storage.postgres.GetOrganization"
+
+	stmt, err := s.prepare(ctx, `SELECT `+organizationColumns+` FROM organization WHERE id = $1`)
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	o, err := scanOrganization(stmt.QueryRowContext(ctx, orgId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, storage.ErrOrganizationNotFound)
+	}
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return o, nil
+}
+
+// GetOrganizationsList returns a page of organizations ordered by name.
+func (s *Storage) GetOrganizationsList(ctx context.Context, page internal.Page) ([]internal.Organization, error) {
+	const op = "storage.postgres.GetOrganizationsList"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT `+organizationColumns+`
+		FROM organization
+		ORDER BY name, id
+		LIMIT $1 OFFSET $2
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+	defer rows.Close()
+
+	organizations := make([]internal.Organization, 0)
+	for rows.Next() {
+		o, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+		organizations = append(organizations, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return organizations, nil
+}
+
+// EditOrganization changes the organization o.Id, empty fields keep their values.
+func (s *Storage) EditOrganization(ctx context.Context, o internal.Organization, actor string) (internal.Organization, error) {
+	var edited internal.Organization
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		edited, err = tx.editOrganization(ctx, o, actor)
+		return err
+	})
+
+	return edited, err
+}
+
+func (s *Storage) editOrganization(ctx context.Context, o internal.Organization, actor string) (internal.Organization, error) {
+	const op = "storage.postgres.EditOrganization"
+
+	err := s.lockOrganization(ctx, o.Id)
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	before, err := s.GetOrganization(ctx, o.Id)
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		UPDATE organization
+		SET name = COALESCE(NULLIF($2, ''), name),
+			description = COALESCE(NULLIF($3, ''), description),
+			type = COALESCE(NULLIF($4, '')::organization_type, type),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+organizationColumns)
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	edited, err := scanOrganization(stmt.QueryRowContext(ctx, o.Id, o.Name, o.Description, o.Type))
+	if errors.Is(err, sql.ErrNoRows) {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, storage.ErrOrganizationNotFound)
+	}
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.OrganizationEvent(internal.ActionEdit, actor, &before, &edited))
+	if err != nil {
+		return internal.Organization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return edited, nil
+}
+
+// DeleteOrganization removes an organization that has neither tenders nor bids.
+func (s *Storage) DeleteOrganization(ctx context.Context, orgId uuid.UUID, actor string) error {
+	return s.withTx(ctx, func(tx *Storage) error {
+		return tx.deleteOrganization(ctx, orgId, actor)
+	})
+}
+
+func (s *Storage) deleteOrganization(ctx context.Context, orgId uuid.UUID, actor string) error {
+	const op = "storage.postgres.DeleteOrganization"
+
+	err := s.lockOrganization(ctx, orgId)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	o, err := s.GetOrganization(ctx, orgId)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	inUse, err := s.prepare(ctx, `
+		SELECT EXISTS(SELECT 1 FROM tender WHERE organization_id = $1)
+			OR EXISTS(SELECT 1 FROM bid WHERE organization_id = $1)
+	`)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	var used bool
+	err = inUse.QueryRowContext(ctx, orgId).Scan(&used)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+	if used {
+		return fmt.Errorf("%s %w", op, storage.ErrOrganizationInUse)
+	}
+
+	stmt, err := s.prepare(ctx, `DELETE FROM organization WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, orgId)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.OrganizationEvent(internal.ActionDelete, actor, &o, nil))
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// GetOrganizationResponsibles returns a page of the organization
+// responsibles ordered by username.
+func (s *Storage) GetOrganizationResponsibles(ctx context.Context, orgId uuid.UUID, page internal.Page) ([]internal.Employee, error) {
+	const op = "storage.postgres.GetOrganizationResponsibles"
+
+	_, err := s.GetOrganization(ctx, orgId)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		SELECT e.id, e.username, COALESCE(e.first_name, ''), COALESCE(e.last_name, '')
+		FROM organization_responsible AS r JOIN employee AS e ON r.user_id = e.id
+		WHERE r.organization_id = $1
+		ORDER BY e.username
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	employees, err := scanEmployees(ctx, stmt, orgId, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return employees, nil
+}
+
+func (s *Storage) AddOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error {
+	return s.withTx(ctx, func(tx *Storage) error {
+		return tx.addOrganizationResponsible(ctx, orgId, username, actor)
+	})
+}
+
+func (s *Storage) addOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error {
+	const op = "storage.postgres.AddOrganizationResponsible"
+
+	err := s.lockOrganization(ctx, orgId)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = s.GetOrgRespId(ctx, orgId, username)
+	if err == nil {
+		return fmt.Errorf("%s %w", op, storage.ErrAlreadyExists)
+	}
+	if !errors.Is(err, storage.ErrOrgRespNotFound) {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	e, err := s.GetEmployeeByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		INSERT INTO organization_responsible(id, organization_id, user_id)
+		VALUES ($1, $2, $3)
+	`)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, uuid.New(), orgId, e.Id)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.ResponsibleEvent(internal.ActionCreate, actor, orgId, e))
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveOrganizationResponsible takes the responsibility away from username.
+// The last responsible of an organization can not be removed.
+func (s *Storage) RemoveOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error {
+	return s.withTx(ctx, func(tx *Storage) error {
+		return tx.removeOrganizationResponsible(ctx, orgId, username, actor)
+	})
+}
+
+func (s *Storage) removeOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error {
+	const op = "storage.postgres.RemoveOrganizationResponsible"
+
+	err := s.lockOrganization(ctx, orgId)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	respId, err := s.GetOrgRespId(ctx, orgId, username)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	n, err := s.responsibleCount(ctx, orgId)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+	if n <= 1 {
+		return fmt.Errorf("%s %w", op, storage.ErrLastResponsible)
+	}
+
+	e, err := s.GetEmployeeByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `DELETE FROM organization_responsible WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, respId)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.ResponsibleEvent(internal.ActionDelete, actor, orgId, e))
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) responsibleCount(ctx context.Context, orgId uuid.UUID) (int, error) {
+	stmt, err := s.prepare(ctx, `SELECT COUNT(*) FROM organization_responsible WHERE organization_id = $1`)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	err = stmt.QueryRowContext(ctx, orgId).Scan(&n)
+
+	return n, err
+}
+
+// IsOrganizationResponsible reports whether username is a responsible
+// of the organization.
+func (s *Storage) IsOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username string) (bool, error) {
+	const op = "storage.postgres.IsOrganizationResponsible"
+
+	_, err := s.GetOrgRespId(ctx, orgId, username)
+	if errors.Is(err, storage.ErrOrgRespNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s %w", op, err)
+	}
+
+	return true, nil
+}
+
+// uniqueErr maps a unique constraint violation to storage.ErrAlreadyExists.
+func uniqueErr(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return storage.ErrAlreadyExists
+	}
+
+	return err
+}
@@ -1,34 +1,53 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	_ "github.com/lib/pq"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"tender-app-backend/src/internal"
 	"tender-app-backend/src/internal/config"
 	"tender-app-backend/src/internal/storage"
+	"tender-app-backend/src/internal/storage/postgres/migrate"
+	"time"
 )
 
+// Storage runs the same way inside and outside a transaction: tx is set
+// on the Storage handed to withTx callbacks and nil otherwise.
 type Storage struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *stmtCache
+	tx    *sql.Tx
 }
 
-func execCreateQuery(db *sql.DB, query string) error {
-	stmt, err := db.Prepare(query)
-	if err != nil {
-		return fmt.Errorf("%s", err)
-	}
-	_, err = stmt.Exec()
-	if err != nil {
-		return fmt.Errorf("%s", err)
-	}
-
-	return nil
-}
-
-func New(cfg *config.Config) (*Storage, error) {
-	const op = "storage.postgres.New"
+// tenderVisible restricts tenders t with status s to the ones the viewer
+// passed as $1 may see: published tenders and tenders of their organization.
+const tenderVisible = `
+	(s.status_type = 'PUBLISHED' OR EXISTS (
+		SELECT 1
+		FROM organization_responsible AS vr JOIN employee AS ve ON vr.user_id = ve.id
+		WHERE ve.username = $1 AND vr.organization_id = t.organization_id))`
+
+// bidVisible restricts bids b with status s to the ones the viewer passed as $1
+// may see: own bids, bids of their organization, and bids on their organization
+// tenders once the bid has left CREATED.
+const bidVisible = `
+	(b.creator_username = $1 OR EXISTS (
+		SELECT 1
+		FROM organization_responsible AS vr JOIN employee AS ve ON vr.user_id = ve.id
+		WHERE ve.username = $1 AND vr.organization_id = b.organization_id
+	) OR (s.status_type <> 'CREATED' AND EXISTS (
+		SELECT 1
+		FROM organization_responsible_tender AS vt JOIN tender AS vtt ON vt.tender_id = vtt.id
+		JOIN organization_responsible AS vr ON vr.organization_id = vtt.organization_id
+		JOIN employee AS ve ON vr.user_id = ve.id
+		WHERE vt.id = b.tender_id AND ve.username = $1)))`
+
+// Connect opens the database described by cfg.
+func Connect(cfg *config.Config) (*sql.DB, error) {
+	const op = "storage.postgres.Connect"
 
 	//connStr := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
 	//	cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Database)
@@ -40,213 +59,159 @@ func New(cfg *config.Config) (*Storage, error) {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	createStatus := `
-	CREATE TABLE IF NOT EXISTS status (
-		id INT PRIMARY KEY,
-		status_type VARCHAR(20)
-	);`
-	err = execCreateQuery(db, createStatus)
-	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
-	}
+	return db, nil
+}
 
-	addCreated, err := db.Prepare("INSERT INTO status(id, status_type) VALUES (1, 'CREATED') ON CONFLICT (id) DO NOTHING")
-	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
-	}
+// New connects to the database and applies pending schema migrations.
+func New(cfg *config.Config) (*Storage, error) {
+	const op = "storage.postgres.New"
 
-	addPublished, err := db.Prepare("INSERT INTO status(id, status_type) VALUES (2, 'PUBLISHED') ON CONFLICT (id) DO NOTHING")
+	db, err := Connect(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	addClosed, err := db.Prepare("INSERT INTO status(id, status_type) VALUES (3, 'CLOSED') ON CONFLICT (id) DO NOTHING")
+	_, err = migrate.Up(db)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	addCanceled, err := db.Prepare("INSERT INTO status(id, status_type) VALUES (4, 'CANCELED') ON CONFLICT (id) DO NOTHING")
-	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
-	}
+	return &Storage{db: db, stmts: newStmtCache(db)}, nil
+}
 
-	_, err = addCreated.Exec()
-	if err != nil {
-		return nil, fmt.Errorf("%s", err)
-	}
+// Close releases the prepared statements and closes the database.
+func (s *Storage) Close() error {
+	const op = "storage.postgres.Close"
 
-	_, err = addPublished.Exec()
+	err := errors.Join(s.stmts.close(), s.db.Close())
 	if err != nil {
-		return nil, fmt.Errorf("%s", err)
+		return fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = addClosed.Exec()
-	if err != nil {
-		return nil, fmt.Errorf("%s", err)
-	}
+	return nil
+}
 
-	_, err = addCanceled.Exec()
-	if err != nil {
-		return nil, fmt.Errorf("%s", err)
-	}
+func (s *Storage) GetOrgRespId(ctx context.Context, orgId uuid.UUID, creatorUsername string) (uuid.UUID, error) {
+	const op = "storage.postgres.GetOrgRespId"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT r.id
+		FROM organization_responsible AS r
+		JOIN employee AS e
+		ON r.user_id = e.id
+		WHERE e.username = $1 AND r.organization_id = $2
+	`)
 
-	createTender := `
-	CREATE TABLE IF NOT EXISTS tender(
-	    id serial PRIMARY KEY,
-	    name VARCHAR(100) NOT NULL,
-	    description TEXT,
-	    service_type VARCHAR(50),
-	    status_id INT REFERENCES status(id) ON DELETE CASCADE,
-	    organization_id INT REFERENCES organization(id) ON DELETE CASCADE,
-	    creator_username VARCHAR(50) NOT NULL,
-	    version INT NOT NULL
-	);`
-	err = execCreateQuery(db, createTender)
 	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	createBid := `
-	CREATE TABLE IF NOT EXISTS bid(
-	    id serial PRIMARY KEY,
-	    name VARCHAR(100) NOT NULL,
-	    description TEXT,
-	    status_id INT REFERENCES status(id) ON DELETE CASCADE,
-	    tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
-	    organization_id INT REFERENCES organization(id) ON DELETE CASCADE,
-	    creator_username VARCHAR(50) NOT NULL,
-	    version INT NOT NULL
-	);`
-	err = execCreateQuery(db, createBid)
+	var idResp uuid.UUID
+	err = stmt.QueryRowContext(ctx, creatorUsername, orgId).Scan(&idResp)
 	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, s.respNotFound(ctx, creatorUsername)
+		}
+		return uuid.Nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	createOrgRespTender := `
-	CREATE TABLE IF NOT EXISTS organization_responsible_tender(
-	    id SERIAL PRIMARY KEY,
-	    org_resp_id INT REFERENCES organization_responsible(id) ON DELETE CASCADE,
-	    tender_id INT REFERENCES tender(id) ON DELETE CASCADE
-	);`
-	err = execCreateQuery(db, createOrgRespTender)
-	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
+	return idResp, nil
+}
+
+// respNotFound explains why username is not a responsible:
+// either there is no such employee or they belong to another organization.
+func (s *Storage) respNotFound(ctx context.Context, username string) error {
+	const op = "storage.postgres.respNotFound"
+
+	if username == "" {
+		return storage.ErrOrgRespNotFound
 	}
 
-	createTenderBid := `
-	CREATE TABLE IF NOT EXISTS tender_bid(
-	    id SERIAL PRIMARY KEY,
-	    tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
-	    bid_id INT REFERENCES bid(id) ON DELETE CASCADE
-	);`
-	err = execCreateQuery(db, createTenderBid)
+	stmt, err := s.prepare(ctx, `SELECT EXISTS(SELECT 1 FROM employee WHERE username = $1)`)
 	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
+		return fmt.Errorf("%s %w", op, err)
 	}
 
-	createTenderVersions := `
-	CREATE TABLE IF NOT EXISTS tender_versions(
-	    id SERIAL PRIMARY KEY,
-	    org_resp_tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
-	    tender_id INT REFERENCES tender(id) ON DELETE CASCADE,
-	    tender_version INT
-	)`
-	err = execCreateQuery(db, createTenderVersions)
+	var exists bool
+	err = stmt.QueryRowContext(ctx, username).Scan(&exists)
 	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
+		return fmt.Errorf("%s %w", op, err)
 	}
 
-	createBidVersions := `
-	CREATE TABLE IF NOT EXISTS bid_versions(
-	    id SERIAL PRIMARY KEY,
-	    tender_bid_id INT REFERENCES tender_bid(id) ON DELETE CASCADE,
-	    bid_id INT REFERENCES bid(id) ON DELETE CASCADE,
-	    bid_version INT
-	)`
-	err = execCreateQuery(db, createBidVersions)
-	if err != nil {
-		return nil, fmt.Errorf("%s %w", op, err)
+	if !exists {
+		return storage.ErrUserNotFound
 	}
 
-	return &Storage{db: db}, nil
+	return storage.ErrOrgRespNotFound
 }
 
-func (s *Storage) GetOrgRespId(orgId int, creatorUsername string) (int, error) {
-	const op = "storage.postgres.GetOrgRespId"
-
-	stmt, err := s.db.Prepare(`
-		SELECT r.id
-		FROM organization_responsible AS r
-		JOIN employee AS e
-		ON r.user_id = e.id
-		WHERE e.username = $1 AND r.organization_id = $2
-	`)
-
-	if err != nil {
-		return 0, fmt.Errorf("%s %w", op, err)
-	}
+func (s *Storage) CreateTender(ctx context.Context, t internal.Tender) (internal.Tender, error) {
+	var created internal.Tender
 
-	var idResp int
-	err = stmt.QueryRow(creatorUsername, orgId).Scan(&idResp)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return 0, storage.ErrOrgRespNotFound
-		}
-		return 0, fmt.Errorf("%s %w", op, err)
-	}
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		created, err = tx.createTender(ctx, t)
+		return err
+	})
 
-	return idResp, nil
+	return created, err
 }
 
-func (s *Storage) CreateTender(t internal.Tender) (internal.Tender, error) {
+func (s *Storage) createTender(ctx context.Context, t internal.Tender) (internal.Tender, error) {
 	const op = "storage.postgres.CreateTender"
 
-	orgRespId, err := s.GetOrgRespId(t.OrganizationId, t.CreatorUsername)
+	orgRespId, err := s.GetOrgRespId(ctx, t.OrganizationId, t.CreatorUsername)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	createTender, err := s.db.Prepare(`
-		INSERT INTO tender(name, description, service_type, status_id, organization_id, creator_username, version)
-		VALUES ($1, $2, $3, 1, $4, $5, 1) RETURNING id
+	createTender, err := s.prepare(ctx, `
+		INSERT INTO tender(name, description, service_type, status_id, organization_id, creator_username, version, bid_deadline)
+		VALUES ($1, $2, $3, 1, $4, $5, 1, $6) RETURNING id
 	`)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
 	var tenderId int
-	err = createTender.QueryRow(t.Name, t.Description, t.ServiceType, t.OrganizationId, t.CreatorUsername).Scan(&tenderId)
+	err = createTender.QueryRowContext(ctx, t.Name, t.Description, t.ServiceType, t.OrganizationId, t.CreatorUsername, deadline(t)).Scan(&tenderId)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	orgRespTenderEntry, err := s.db.Prepare(`
-		INSERT INTO organization_responsible_tender(org_resp_id, tender_id)
-		VALUES ($1, $2) RETURNING id
+	orgRespTenderEntry, err := s.prepare(ctx, `
+		INSERT INTO organization_responsible_tender(org_resp_id, tender_id, last_edited_by)
+		VALUES ($1, $2, $3) RETURNING id, created_at, updated_at
 	`)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	var id int
-	err = orgRespTenderEntry.QueryRow(orgRespId, tenderId).Scan(&id)
+	var id uuid.UUID
+	err = orgRespTenderEntry.QueryRowContext(ctx, orgRespId, tenderId, t.CreatorUsername).Scan(&id, &t.CreatedAt, &t.UpdatedAt)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
 	t.Id = id
 	t.Version = 1
-	t.Status = "CREATED"
+	t.Status = internal.TenderCreated
+	t.LastEditedBy = t.CreatorUsername
 
-	tenderVersionEntry, err := s.db.Prepare(`
-		INSERT INTO tender_versions(org_resp_tender_id, tender_id, tender_version)
-		VALUES ($1, $2, $3)
+	tenderVersionEntry, err := s.prepare(ctx, `
+		INSERT INTO tender_versions(org_resp_tender_id, tender_id, tender_version, author)
+		VALUES ($1, $2, $3, $4)
 	`)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = tenderVersionEntry.Exec(id, tenderId, t.Version)
+	_, err = tenderVersionEntry.ExecContext(ctx, id, tenderId, t.Version, t.CreatorUsername)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.TenderEvent(internal.ActionCreate, t.CreatorUsername, nil, t))
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
@@ -254,10 +219,10 @@ func (s *Storage) CreateTender(t internal.Tender) (internal.Tender, error) {
 	return t, nil
 }
 
-func (s *Storage) PublishTender(id int) error {
+func (s *Storage) PublishTender(ctx context.Context, id uuid.UUID) error {
 	const op = "storage.postgres.PublishTender"
 
-	stmt, err := s.db.Prepare(`
+	stmt, err := s.prepare(ctx, `
 		UPDATE tender
 		SET status_id = (SELECT id FROM status WHERE status_type='PUBLISHED')
 		WHERE id=(SELECT tender_id FROM organization_responsible_tender WHERE id=$1)
@@ -266,7 +231,7 @@ func (s *Storage) PublishTender(id int) error {
 		return fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s %w", op, err)
 	}
@@ -274,10 +239,10 @@ func (s *Storage) PublishTender(id int) error {
 	return nil
 }
 
-func (s *Storage) CloseTender(id int) error {
+func (s *Storage) CloseTender(ctx context.Context, id uuid.UUID) error {
 	const op = "storage.postgres.CloseTender"
 
-	stmt, err := s.db.Prepare(`
+	stmt, err := s.prepare(ctx, `
 		UPDATE tender
 		SET status_id = (SELECT id FROM status WHERE status_type='CLOSED')
 		WHERE id=(SELECT tender_id FROM organization_responsible_tender WHERE id=$1)
@@ -286,7 +251,7 @@ func (s *Storage) CloseTender(id int) error {
 		return fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s %w", op, err)
 	}
@@ -294,13 +259,144 @@ func (s *Storage) CloseTender(id int) error {
 	return nil
 }
 
-func (s *Storage) GetTendersList() ([]internal.Tender, error) {
+// touchTender records that username changed the tender just now
+// and returns the new update time.
+func (s *Storage) touchTender(ctx context.Context, tenderId uuid.UUID, username string) (time.Time, error) {
+	const op = "storage.postgres.touchTender"
+
+	stmt, err := s.prepare(ctx, `
+		UPDATE organization_responsible_tender
+		SET updated_at = CURRENT_TIMESTAMP, last_edited_by = $2
+		WHERE id = $1
+		RETURNING updated_at
+	`)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	var updatedAt time.Time
+
+	err = stmt.QueryRowContext(ctx, tenderId, username).Scan(&updatedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return updatedAt, nil
+}
+
+func (s *Storage) GetTender(ctx context.Context, tenderId uuid.UUID) (internal.Tender, error) {
+	const op = "storage.postgres.GetTender"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT r.id, t.name, t.description, t.service_type, s.status_type, t.organization_id, t.creator_username, t.version,
+		       r.created_at, r.updated_at, COALESCE(r.last_edited_by, ''), t.bid_deadline
+		FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
+		JOIN status AS s ON t.status_id = s.id
+		WHERE r.id = $1
+	`)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	var t internal.Tender
+
+	err = stmt.QueryRowContext(ctx, tenderId).Scan(&t.Id, &t.Name, &t.Description, &t.ServiceType, &t.Status, &t.OrganizationId, &t.CreatorUsername, &t.Version, &t.CreatedAt, &t.UpdatedAt, &t.LastEditedBy, &t.BidDeadline)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.Tender{}, storage.ErrTenderNotFound
+		}
+
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return t, nil
+}
+
+// checkTenderVisible reports whether the viewer may see the tender.
+func (s *Storage) checkTenderVisible(ctx context.Context, v internal.Viewer, t internal.Tender) error {
+	if t.Status == internal.TenderPublished {
+		return nil
+	}
+
+	_, err := s.GetOrgRespId(ctx, t.OrganizationId, v.Username)
+
+	return err
+}
+
+func (s *Storage) UpdateTenderStatus(ctx context.Context, tenderId uuid.UUID, status, username string) (internal.Tender, error) {
+	var updated internal.Tender
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		updated, err = tx.updateTenderStatus(ctx, tenderId, status, username)
+		return err
+	})
+
+	return updated, err
+}
+
+func (s *Storage) updateTenderStatus(ctx context.Context, tenderId uuid.UUID, status, username string) (internal.Tender, error) {
+	const op = "storage.postgres.UpdateTenderStatus"
+
+	err := s.lockTender(ctx, tenderId)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	t, err := s.GetTender(ctx, tenderId)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = s.GetOrgRespId(ctx, t.OrganizationId, username)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if !internal.CanChangeTenderStatus(t.Status, status) {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, storage.ErrInvalidTransition)
+	}
+
+	switch status {
+	case internal.TenderPublished:
+		err = s.PublishTender(ctx, tenderId)
+	case internal.TenderClosed:
+		err = s.CloseTender(ctx, tenderId)
+	}
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	before := t
+
+	t.UpdatedAt, err = s.touchTender(ctx, tenderId, username)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	t.Status = status
+	t.LastEditedBy = username
+
+	err = s.recordAudit(ctx, storage.TenderEvent(internal.StatusAction(status), username, &before, t))
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return t, nil
+}
+
+func (s *Storage) GetTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
 	const op = "storage.postgres.GetTendersList"
 
-	stmt, err := s.db.Prepare(`
-		SELECT r.id, t.name, t.description, t.service_type, s.status_type, t.organization_id, t.creator_username, t.version
+	stmt, err := s.prepare(ctx, `
+		SELECT r.id, t.name, t.description, t.service_type, s.status_type, t.organization_id, t.creator_username, t.version,
+		       r.created_at, r.updated_at, COALESCE(r.last_edited_by, ''), t.bid_deadline
 		FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
 		JOIN status as s ON t.status_id = s.id
+		WHERE (COALESCE(cardinality($2::text[]), 0) = 0 OR t.service_type = ANY($2::text[]))
+		  AND `+tenderVisible+`
+		ORDER BY t.name, r.id
+		LIMIT $3 OFFSET $4
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
@@ -308,14 +404,15 @@ func (s *Storage) GetTendersList() ([]internal.Tender, error) {
 
 	tenders := make([]internal.Tender, 0)
 
-	rows, err := stmt.Query()
+	rows, err := stmt.QueryContext(ctx, v.Username, pq.Array(serviceTypes), page.Limit, page.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var t internal.Tender
-		err = rows.Scan(&t.Id, &t.Name, &t.Description, &t.ServiceType, &t.Status, &t.OrganizationId, &t.CreatorUsername, &t.Version)
+		err = rows.Scan(&t.Id, &t.Name, &t.Description, &t.ServiceType, &t.Status, &t.OrganizationId, &t.CreatorUsername, &t.Version, &t.CreatedAt, &t.UpdatedAt, &t.LastEditedBy, &t.BidDeadline)
 		if err != nil {
 			return nil, fmt.Errorf("%s %w", op, err)
 		}
@@ -328,14 +425,19 @@ func (s *Storage) GetTendersList() ([]internal.Tender, error) {
 	return tenders, nil
 }
 
-func (s *Storage) GetUserTendersList(username string) ([]internal.Tender, error) {
+func (s *Storage) GetUserTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error) {
 	const op = "storage.postgres.GetUserTendersList"
 
-	stmt, err := s.db.Prepare(`
-		SELECT r.id, t.name, t.description, t.service_type, s.status_type, t.organization_id, t.creator_username, t.version
+	stmt, err := s.prepare(ctx, `
+		SELECT r.id, t.name, t.description, t.service_type, s.status_type, t.organization_id, t.creator_username, t.version,
+		       r.created_at, r.updated_at, COALESCE(r.last_edited_by, ''), t.bid_deadline
 		FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
 		JOIN status as s ON t.status_id = s.id
 		WHERE t.creator_username=$1
+		  AND (COALESCE(cardinality($2::text[]), 0) = 0 OR t.service_type = ANY($2::text[]))
+		  AND `+tenderVisible+`
+		ORDER BY t.name, r.id
+		LIMIT $3 OFFSET $4
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
@@ -343,14 +445,15 @@ func (s *Storage) GetUserTendersList(username string) ([]internal.Tender, error)
 
 	tenders := make([]internal.Tender, 0)
 
-	rows, err := stmt.Query(username)
+	rows, err := stmt.QueryContext(ctx, v.Username, pq.Array(serviceTypes), page.Limit, page.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var t internal.Tender
-		err = rows.Scan(&t.Id, &t.Name, &t.Description, &t.ServiceType, &t.Status, &t.OrganizationId, &t.CreatorUsername, &t.Version)
+		err = rows.Scan(&t.Id, &t.Name, &t.Description, &t.ServiceType, &t.Status, &t.OrganizationId, &t.CreatorUsername, &t.Version, &t.CreatedAt, &t.UpdatedAt, &t.LastEditedBy, &t.BidDeadline)
 		if err != nil {
 			return nil, fmt.Errorf("%s %w", op, err)
 		}
@@ -363,17 +466,17 @@ func (s *Storage) GetUserTendersList(username string) ([]internal.Tender, error)
 	return tenders, nil
 }
 
-func (s *Storage) GetStatusId(status string) (int, error) {
+func (s *Storage) GetStatusId(ctx context.Context, status string) (int, error) {
 	const op = "storage.postgres.GetStatusId"
 
-	stmt, err := s.db.Prepare("SELECT id FROM status WHERE status_type = $1")
+	stmt, err := s.prepare(ctx, "SELECT id FROM status WHERE status_type = $1")
 	if err != nil {
 		return 0, fmt.Errorf("%s %w", op, err)
 	}
 
 	var id int
 
-	err = stmt.QueryRow(status).Scan(&id)
+	err = stmt.QueryRowContext(ctx, status).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("%s %w", op, err)
 	}
@@ -381,17 +484,17 @@ func (s *Storage) GetStatusId(status string) (int, error) {
 	return id, nil
 }
 
-func (s *Storage) GetTenderVersion(tenderId int) (int, error) {
+func (s *Storage) GetTenderVersion(ctx context.Context, tenderId uuid.UUID) (int, error) {
 	const op = "storage.postgres.GetTenderVersion"
 
-	stmt, err := s.db.Prepare("SELECT MAX(tender_version) FROM tender_versions WHERE org_resp_tender_id = $1")
+	stmt, err := s.prepare(ctx, "SELECT MAX(tender_version) FROM tender_versions WHERE org_resp_tender_id = $1")
 	if err != nil {
 		return 0, fmt.Errorf("%s %w", op, err)
 	}
 
 	var version int
 
-	err = stmt.QueryRow(tenderId).Scan(&version)
+	err = stmt.QueryRowContext(ctx, tenderId).Scan(&version)
 	if err != nil {
 		return 0, fmt.Errorf("%s %w", op, err)
 	}
@@ -399,35 +502,46 @@ func (s *Storage) GetTenderVersion(tenderId int) (int, error) {
 	return version, nil
 }
 
-func (s *Storage) EditTender(t internal.Tender, editId int) (internal.Tender, error) {
+func (s *Storage) EditTender(ctx context.Context, t internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error) {
+	var edited internal.Tender
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		edited, err = tx.editTender(ctx, internal.ActionEdit, t, editId, expected, username)
+		return err
+	})
+
+	return edited, err
+}
+
+// editTender applies the edit on top of the current version and records
+// username as its author, and the action in the audit log. A non-zero
+// expected version must be the current one, otherwise the edit is refused
+// with storage.ErrVersionConflict.
+func (s *Storage) editTender(ctx context.Context, action string, t internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error) {
 	const op = "storage.postgres.EditTender"
 
-	getTender, err := s.db.Prepare(`
-		SELECT t.name, t.description, t.service_type, s.status_type, t.organization_id, t.creator_username, t.version
-		FROM tender AS t JOIN organization_responsible_tender AS r ON t.id = r.tender_id
-		JOIN status AS s ON t.status_id = s.id
-		WHERE r.id = $1
-	`)
+	err := s.lockTender(ctx, editId)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	var edit internal.Tender
-
-	err = getTender.QueryRow(editId).Scan(&edit.Name, &edit.Description, &edit.ServiceType, &edit.Status, &edit.OrganizationId, &edit.CreatorUsername, &edit.Version)
+	before, err := s.GetTender(ctx, editId)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return internal.Tender{}, storage.ErrTenderNotFound
-		}
-
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	actualVer, err := s.GetTenderVersion(editId)
+	edit := before
+
+	actualVer, err := s.GetTenderVersion(ctx, editId)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
+	if expected != 0 && expected != actualVer {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, storage.ErrVersionConflict)
+	}
+
 	if t.Name != "" {
 		edit.Name = t.Name
 	}
@@ -437,18 +551,22 @@ func (s *Storage) EditTender(t internal.Tender, editId int) (internal.Tender, er
 	if t.ServiceType != "" {
 		edit.ServiceType = t.ServiceType
 	}
+	// A rollback restores the deadline even when the version had none.
+	if t.BidDeadline != nil || action == internal.ActionRollback {
+		edit.BidDeadline = t.BidDeadline
+	}
 
 	edit.Id = editId
 	edit.Version = actualVer + 1
 
-	statusId, err := s.GetStatusId(edit.Status)
+	statusId, err := s.GetStatusId(ctx, edit.Status)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	createTender, err := s.db.Prepare(`
-		INSERT INTO tender(name, description, service_type, status_id, organization_id, creator_username, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+	createTender, err := s.prepare(ctx, `
+		INSERT INTO tender(name, description, service_type, status_id, organization_id, creator_username, version, bid_deadline)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
 	`)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
@@ -456,34 +574,42 @@ func (s *Storage) EditTender(t internal.Tender, editId int) (internal.Tender, er
 
 	var tenderId int
 
-	err = createTender.QueryRow(edit.Name, edit.Description, edit.ServiceType, statusId, edit.OrganizationId, edit.CreatorUsername, edit.Version).Scan(&tenderId)
+	err = createTender.QueryRowContext(ctx, edit.Name, edit.Description, edit.ServiceType, statusId, edit.OrganizationId, edit.CreatorUsername, edit.Version, deadline(edit)).Scan(&tenderId)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	updateOrgRespTend, err := s.db.Prepare(`
+	updateOrgRespTend, err := s.prepare(ctx, `
 		UPDATE organization_responsible_tender
-		SET tender_id = $1
+		SET tender_id = $1, updated_at = CURRENT_TIMESTAMP, last_edited_by = $3
 		WHERE id = $2
+		RETURNING updated_at
 	`)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = updateOrgRespTend.Exec(tenderId, editId)
+	err = updateOrgRespTend.QueryRowContext(ctx, tenderId, editId, username).Scan(&edit.UpdatedAt)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	tenderVersionEntry, err := s.db.Prepare(`
-		INSERT INTO tender_versions(org_resp_tender_id, tender_id, tender_version)
-		VALUES ($1, $2, $3)
+	edit.LastEditedBy = username
+
+	tenderVersionEntry, err := s.prepare(ctx, `
+		INSERT INTO tender_versions(org_resp_tender_id, tender_id, tender_version, author)
+		VALUES ($1, $2, $3, $4)
 	`)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = tenderVersionEntry.Exec(editId, tenderId, edit.Version)
+	_, err = tenderVersionEntry.ExecContext(ctx, editId, tenderId, edit.Version, username)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.TenderEvent(action, username, &before, edit))
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
@@ -491,44 +617,45 @@ func (s *Storage) EditTender(t internal.Tender, editId int) (internal.Tender, er
 	return edit, nil
 }
 
-func (s *Storage) RollbackTender(tenderId, version int) (internal.Tender, error) {
+func (s *Storage) RollbackTender(ctx context.Context, tenderId uuid.UUID, version, expected int, username string) (internal.Tender, error) {
+	var rolledBack internal.Tender
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		rolledBack, err = tx.rollbackTender(ctx, tenderId, version, expected, username)
+		return err
+	})
+
+	return rolledBack, err
+}
+
+func (s *Storage) rollbackTender(ctx context.Context, tenderId uuid.UUID, version, expected int, username string) (internal.Tender, error) {
 	const op = "storage.postgres.RollbackTender"
 
-	getPrev, err := s.db.Prepare(`
-		SELECT t.name, t.description, t.service_type, s.status_type, t.organization_id, t.creator_username, t.version
-		FROM tender_versions AS v JOIN tender AS t ON t.id = v.tender_id
-		JOIN status AS s ON t.status_id = s.id
-		WHERE v.org_resp_tender_id = $1 AND v.tender_version = $2
-	`)
+	err := s.lockTender(ctx, tenderId)
 	if err != nil {
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	var prev internal.Tender
-
-	err = getPrev.QueryRow(tenderId, version).Scan(&prev.Name, &prev.Description, &prev.ServiceType, &prev.Status, &prev.OrganizationId, &prev.CreatorUsername, &prev.Version)
+	prev, err := s.GetTenderRevision(ctx, tenderId, version)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return internal.Tender{}, storage.ErrTenderNotFound
-		}
-
 		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	return s.EditTender(prev, tenderId)
+	return s.editTender(ctx, internal.ActionRollback, prev, tenderId, expected, username)
 }
 
-func (s *Storage) CheckTenderExist(tenderId int) (bool, error) {
+func (s *Storage) CheckTenderExist(ctx context.Context, tenderId uuid.UUID) (bool, error) {
 	const op = "storage.postgres.CheckTenderExist"
 
-	stmt, err := s.db.Prepare("SELECT id FROM organization_responsible_tender WHERE id = $1")
+	stmt, err := s.prepare(ctx, "SELECT id FROM organization_responsible_tender WHERE id = $1")
 	if err != nil {
 		return false, fmt.Errorf("%s %w", op, err)
 	}
 
-	var res int
+	var res uuid.UUID
 
-	err = stmt.QueryRow(tenderId).Scan(&res)
+	err = stmt.QueryRowContext(ctx, tenderId).Scan(&res)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, storage.ErrTenderNotFound
@@ -540,20 +667,37 @@ func (s *Storage) CheckTenderExist(tenderId int) (bool, error) {
 	return true, nil
 }
 
-func (s *Storage) CreateBid(b internal.Bid) (internal.Bid, error) {
+func (s *Storage) CreateBid(ctx context.Context, b internal.Bid) (internal.Bid, error) {
+	var created internal.Bid
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		created, err = tx.createBid(ctx, b)
+		return err
+	})
+
+	return created, err
+}
+
+func (s *Storage) createBid(ctx context.Context, b internal.Bid) (internal.Bid, error) {
 	const op = "storage.postgres.CreateBid"
 
-	_, err := s.GetOrgRespId(b.OrganizationId, b.CreatorUsername)
+	_, err := s.GetOrgRespId(ctx, b.OrganizationId, b.CreatorUsername)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = s.CheckTenderExist(ctx, b.TenderId)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = s.CheckTenderExist(b.TenderId)
+	err = s.checkBidDeadline(ctx, b.TenderId)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	createBid, err := s.db.Prepare(`
+	createBid, err := s.prepare(ctx, `
 		INSERT INTO bid(name, description, status_id, tender_id, organization_id, creator_username, version)
 		VALUES ($1, $2, 1, $3, $4, $5, 1) RETURNING id
 	`)
@@ -562,38 +706,44 @@ func (s *Storage) CreateBid(b internal.Bid) (internal.Bid, error) {
 	}
 
 	var bidId int
-	err = createBid.QueryRow(b.Name, b.Description, b.TenderId, b.OrganizationId, b.CreatorUsername).Scan(&bidId)
+	err = createBid.QueryRowContext(ctx, b.Name, b.Description, b.TenderId, b.OrganizationId, b.CreatorUsername).Scan(&bidId)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	tenderBidEntry, err := s.db.Prepare(`
-		INSERT INTO tender_bid(tender_id, bid_id)
-		VALUES ($1, $2) RETURNING id
+	tenderBidEntry, err := s.prepare(ctx, `
+		INSERT INTO tender_bid(tender_id, bid_id, last_edited_by)
+		VALUES ($1, $2, $3) RETURNING id, created_at, updated_at
 	`)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	var id int
-	err = tenderBidEntry.QueryRow(b.TenderId, bidId).Scan(&id)
+	var id uuid.UUID
+	err = tenderBidEntry.QueryRowContext(ctx, b.TenderId, bidId, b.CreatorUsername).Scan(&id, &b.CreatedAt, &b.UpdatedAt)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
 	b.Id = id
 	b.Version = 1
-	b.Status = "CREATED"
+	b.Status = internal.BidCreated
+	b.LastEditedBy = b.CreatorUsername
 
-	bidVersionEntry, err := s.db.Prepare(`
-		INSERT INTO bid_versions(tender_bid_id, bid_id, bid_version)
-		VALUES ($1, $2, $3)
+	bidVersionEntry, err := s.prepare(ctx, `
+		INSERT INTO bid_versions(tender_bid_id, bid_id, bid_version, author)
+		VALUES ($1, $2, $3, $4)
 	`)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = bidVersionEntry.Exec(id, bidId, b.Version)
+	_, err = bidVersionEntry.ExecContext(ctx, id, bidId, b.Version, b.CreatorUsername)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.BidEvent(internal.ActionCreate, b.CreatorUsername, b.OrganizationId, nil, b))
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
@@ -601,10 +751,10 @@ func (s *Storage) CreateBid(b internal.Bid) (internal.Bid, error) {
 	return b, nil
 }
 
-func (s *Storage) PublishBid(id int) error {
+func (s *Storage) PublishBid(ctx context.Context, id uuid.UUID) error {
 	const op = "storage.postgres.PublishBid"
 
-	stmt, err := s.db.Prepare(`
+	stmt, err := s.prepare(ctx, `
 		UPDATE bid
 		SET status_id = (SELECT id FROM status WHERE status_type='PUBLISHED')
 		WHERE id = (SELECT bid_id FROM tender_bid WHERE id=$1)
@@ -613,7 +763,7 @@ func (s *Storage) PublishBid(id int) error {
 		return fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s %w", op, err)
 	}
@@ -621,10 +771,10 @@ func (s *Storage) PublishBid(id int) error {
 	return nil
 }
 
-func (s *Storage) CancelBid(id int) error {
+func (s *Storage) CancelBid(ctx context.Context, id uuid.UUID) error {
 	const op = "storage.postgres.CancelBid"
 
-	stmt, err := s.db.Prepare(`
+	stmt, err := s.prepare(ctx, `
 		UPDATE bid
 		SET status_id = (SELECT id FROM status WHERE status_type='CANCELED')
 		WHERE id = (SELECT bid_id FROM tender_bid WHERE id=$1)
@@ -633,7 +783,7 @@ func (s *Storage) CancelBid(id int) error {
 		return fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s %w", op, err)
 	}
@@ -641,106 +791,304 @@ func (s *Storage) CancelBid(id int) error {
 	return nil
 }
 
-func (s *Storage) GetBidVersion(bidId int) (int, error) {
-	const op = "storage.postgres.GetBidVersion"
+func (s *Storage) ApproveBid(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.postgres.ApproveBid"
 
-	stmt, err := s.db.Prepare("SELECT MAX(bid_version) FROM bid_versions WHERE tender_bid_id = $1")
+	stmt, err := s.prepare(ctx, `
+		UPDATE bid
+		SET status_id = (SELECT id FROM status WHERE status_type='APPROVED')
+		WHERE id = (SELECT bid_id FROM tender_bid WHERE id=$1)
+	`)
 	if err != nil {
-		return 0, fmt.Errorf("%s %w", op, err)
+		return fmt.Errorf("%s %w", op, err)
 	}
 
-	var version int
-
-	err = stmt.QueryRow(bidId).Scan(&version)
+	_, err = stmt.ExecContext(ctx, id)
 	if err != nil {
-		return 0, fmt.Errorf("%s %w", op, err)
+		return fmt.Errorf("%s %w", op, err)
 	}
 
-	return version, nil
+	return nil
 }
 
-func (s *Storage) EditBid(b internal.Bid, editId int) (internal.Bid, error) {
-	const op = "storage.postgres.EditBid"
+func (s *Storage) RejectBid(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.postgres.RejectBid"
 
-	getBid, err := s.db.Prepare(`
-		SELECT b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version
-		FROM bid AS b JOIN tender_bid AS t ON b.id = t.bid_id
-		JOIN status AS s ON b.status_id = s.id
-		WHERE t.id = $1
+	stmt, err := s.prepare(ctx, `
+		UPDATE bid
+		SET status_id = (SELECT id FROM status WHERE status_type='REJECTED')
+		WHERE id = (SELECT bid_id FROM tender_bid WHERE id=$1)
 	`)
 	if err != nil {
-		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		return fmt.Errorf("%s %w", op, err)
 	}
 
-	var edit internal.Bid
-
-	err = getBid.QueryRow(editId).Scan(&edit.Name, &edit.Description, &edit.Status, &edit.TenderId, &edit.OrganizationId, &edit.CreatorUsername, &edit.Version)
+	_, err = stmt.ExecContext(ctx, id)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return internal.Bid{}, storage.ErrBidNotFound
-		}
-
-		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		return fmt.Errorf("%s %w", op, err)
 	}
 
-	actualVer, err := s.GetBidVersion(editId)
-	if err != nil {
-		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
-	}
+	return nil
+}
 
-	if b.Name != "" {
-		edit.Name = b.Name
-	}
-	if b.Description != "" {
-		edit.Description = b.Description
+// touchBid records that username changed the bid just now
+// and returns the new update time.
+func (s *Storage) touchBid(ctx context.Context, bidId uuid.UUID, username string) (time.Time, error) {
+	const op = "storage.postgres.touchBid"
+
+	stmt, err := s.prepare(ctx, `
+		UPDATE tender_bid
+		SET updated_at = CURRENT_TIMESTAMP, last_edited_by = $2
+		WHERE id = $1
+		RETURNING updated_at
+	`)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	edit.Id = editId
-	edit.Version = actualVer + 1
+	var updatedAt time.Time
 
-	statusId, err := s.GetStatusId(edit.Status)
+	err = stmt.QueryRowContext(ctx, bidId, username).Scan(&updatedAt)
 	if err != nil {
-		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		return time.Time{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	createBid, err := s.db.Prepare(`
-		INSERT INTO bid(name, description, status_id, tender_id, organization_id, creator_username, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+	return updatedAt, nil
+}
+
+func (s *Storage) GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error) {
+	const op = "storage.postgres.GetBid"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT t.id, b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version,
+		       t.created_at, t.updated_at, COALESCE(t.last_edited_by, '')
+		FROM tender_bid AS t JOIN bid AS b ON t.bid_id = b.id
+		JOIN status AS s ON b.status_id = s.id
+		WHERE t.id = $1
 	`)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	var bidId int
+	var b internal.Bid
 
-	err = createBid.QueryRow(edit.Name, edit.Description, statusId, edit.TenderId, edit.OrganizationId, edit.CreatorUsername, edit.Version).Scan(&bidId)
+	err = stmt.QueryRowContext(ctx, bidId).Scan(&b.Id, &b.Name, &b.Description, &b.Status, &b.TenderId, &b.OrganizationId, &b.CreatorUsername, &b.Version, &b.CreatedAt, &b.UpdatedAt, &b.LastEditedBy)
 	if err != nil {
-		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.Bid{}, storage.ErrBidNotFound
+		}
+
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return b, nil
+}
+
+// checkBidAuthor reports whether username is the bid author
+// or a responsible of the author organization.
+func (s *Storage) checkBidAuthor(ctx context.Context, b internal.Bid, username string) error {
+	if b.CreatorUsername == username {
+		return nil
+	}
+
+	_, err := s.GetOrgRespId(ctx, b.OrganizationId, username)
+
+	return err
+}
+
+func (s *Storage) UpdateBidStatus(ctx context.Context, bidId uuid.UUID, status, username string) (internal.Bid, error) {
+	var updated internal.Bid
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		updated, err = tx.updateBidStatus(ctx, bidId, status, username)
+		return err
+	})
+
+	return updated, err
+}
+
+func (s *Storage) updateBidStatus(ctx context.Context, bidId uuid.UUID, status, username string) (internal.Bid, error) {
+	const op = "storage.postgres.UpdateBidStatus"
+
+	err := s.lockBid(ctx, bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	b, err := s.GetBid(ctx, bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.checkBidAuthor(ctx, b, username)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if internal.IsBidOutcome(status) || !internal.CanChangeBidStatus(b.Status, status) {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrInvalidTransition)
+	}
+
+	if status == internal.BidPublished {
+		err = s.checkBidDeadline(ctx, b.TenderId)
+		if err != nil {
+			return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	switch status {
+	case internal.BidPublished:
+		err = s.PublishBid(ctx, bidId)
+	case internal.BidCanceled:
+		err = s.CancelBid(ctx, bidId)
+	}
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	before := b
+
+	b.UpdatedAt, err = s.touchBid(ctx, bidId, username)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	b.Status = status
+	b.LastEditedBy = username
+
+	err = s.recordAudit(ctx, storage.BidEvent(internal.StatusAction(status), username, b.OrganizationId, &before, b))
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return b, nil
+}
+
+func (s *Storage) GetBidVersion(ctx context.Context, bidId uuid.UUID) (int, error) {
+	const op = "storage.postgres.GetBidVersion"
+
+	stmt, err := s.prepare(ctx, "SELECT MAX(bid_version) FROM bid_versions WHERE tender_bid_id = $1")
+	if err != nil {
+		return 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	var version int
+
+	err = stmt.QueryRowContext(ctx, bidId).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	return version, nil
+}
+
+func (s *Storage) EditBid(ctx context.Context, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error) {
+	var edited internal.Bid
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		edited, err = tx.editBid(ctx, internal.ActionEdit, b, editId, expected, username)
+		return err
+	})
+
+	return edited, err
+}
+
+// editBid applies the edit on top of the current version and records
+// username as its author, and the action in the audit log. A non-zero
+// expected version must be the current one, otherwise the edit is refused
+// with storage.ErrVersionConflict.
+func (s *Storage) editBid(ctx context.Context, action string, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error) {
+	const op = "storage.postgres.EditBid"
+
+	err := s.lockBid(ctx, editId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	before, err := s.GetBid(ctx, editId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	edit := before
+
+	actualVer, err := s.GetBidVersion(ctx, editId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if expected != 0 && expected != actualVer {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrVersionConflict)
+	}
+
+	err = s.checkBidDeadline(ctx, edit.TenderId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if b.Name != "" {
+		edit.Name = b.Name
+	}
+	if b.Description != "" {
+		edit.Description = b.Description
+	}
+
+	edit.Id = editId
+	edit.Version = actualVer + 1
+
+	statusId, err := s.GetStatusId(ctx, edit.Status)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	createBid, err := s.prepare(ctx, `
+		INSERT INTO bid(name, description, status_id, tender_id, organization_id, creator_username, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+	`)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	updateTenderBid, err := s.db.Prepare(`
+	var bidId int
+
+	err = createBid.QueryRowContext(ctx, edit.Name, edit.Description, statusId, edit.TenderId, edit.OrganizationId, edit.CreatorUsername, edit.Version).Scan(&bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	updateTenderBid, err := s.prepare(ctx, `
 		UPDATE tender_bid
-		SET bid_id = $1
+		SET bid_id = $1, updated_at = CURRENT_TIMESTAMP, last_edited_by = $3
 		WHERE id = $2
+		RETURNING updated_at
 	`)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = updateTenderBid.Exec(bidId, editId)
+	err = updateTenderBid.QueryRowContext(ctx, bidId, editId, username).Scan(&edit.UpdatedAt)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	bidVersionEntry, err := s.db.Prepare(`
-		INSERT INTO bid_versions(tender_bid_id, bid_id, bid_version)
-		VALUES ($1, $2, $3)
+	edit.LastEditedBy = username
+
+	bidVersionEntry, err := s.prepare(ctx, `
+		INSERT INTO bid_versions(tender_bid_id, bid_id, bid_version, author)
+		VALUES ($1, $2, $3, $4)
 	`)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = bidVersionEntry.Exec(editId, bidId, edit.Version)
+	_, err = bidVersionEntry.ExecContext(ctx, editId, bidId, edit.Version, username)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.BidEvent(action, username, edit.OrganizationId, &before, edit))
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
@@ -748,41 +1096,45 @@ func (s *Storage) EditBid(b internal.Bid, editId int) (internal.Bid, error) {
 	return edit, nil
 }
 
-func (s *Storage) RollbackBid(bidId, version int) (internal.Bid, error) {
+func (s *Storage) RollbackBid(ctx context.Context, bidId uuid.UUID, version, expected int, username string) (internal.Bid, error) {
+	var rolledBack internal.Bid
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		rolledBack, err = tx.rollbackBid(ctx, bidId, version, expected, username)
+		return err
+	})
+
+	return rolledBack, err
+}
+
+func (s *Storage) rollbackBid(ctx context.Context, bidId uuid.UUID, version, expected int, username string) (internal.Bid, error) {
 	const op = "storage.postgres.RollbackBid"
 
-	getPrev, err := s.db.Prepare(`
-		SELECT b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version
-		FROM bid_versions AS v JOIN bid AS b ON b.id = v.bid_id
-		JOIN status AS s ON b.status_id = s.id
-		WHERE v.tender_bid_id = $1 AND v.bid_version = $2
-	`)
+	err := s.lockBid(ctx, bidId)
 	if err != nil {
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	var prev internal.Bid
-
-	err = getPrev.QueryRow(bidId, version).Scan(&prev.Name, &prev.Description, &prev.Status, &prev.TenderId, &prev.OrganizationId, &prev.CreatorUsername, &prev.Version)
+	prev, err := s.GetBidRevision(ctx, bidId, version)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return internal.Bid{}, storage.ErrBidNotFound
-		}
-
 		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
 	}
 
-	return s.EditBid(prev, bidId)
+	return s.editBid(ctx, internal.ActionRollback, prev, bidId, expected, username)
 }
 
-func (s *Storage) GetUserBidsList(username string) ([]internal.Bid, error) {
+func (s *Storage) GetUserBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error) {
 	const op = "storage.postgres.GetUserBidsList"
 
-	stmt, err := s.db.Prepare(`
-		SELECT t.id, b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version
+	stmt, err := s.prepare(ctx, `
+		SELECT t.id, b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version,
+		       t.created_at, t.updated_at, COALESCE(t.last_edited_by, '')
 		FROM tender_bid AS t JOIN bid AS b ON t.bid_id = b.id
 		JOIN status as s ON b.status_id = s.id
 		WHERE b.creator_username = $1
+		ORDER BY b.name, t.id
+		LIMIT $2 OFFSET $3
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
@@ -790,14 +1142,15 @@ func (s *Storage) GetUserBidsList(username string) ([]internal.Bid, error) {
 
 	bids := make([]internal.Bid, 0)
 
-	rows, err := stmt.Query(username)
+	rows, err := stmt.QueryContext(ctx, v.Username, page.Limit, page.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var b internal.Bid
-		err = rows.Scan(&b.Id, &b.Name, &b.Description, &b.Status, &b.TenderId, &b.OrganizationId, &b.CreatorUsername, &b.Version)
+		err = rows.Scan(&b.Id, &b.Name, &b.Description, &b.Status, &b.TenderId, &b.OrganizationId, &b.CreatorUsername, &b.Version, &b.CreatedAt, &b.UpdatedAt, &b.LastEditedBy)
 		if err != nil {
 			return nil, fmt.Errorf("%s %w", op, err)
 		}
@@ -810,19 +1163,27 @@ func (s *Storage) GetUserBidsList(username string) ([]internal.Bid, error) {
 	return bids, nil
 }
 
-func (s *Storage) GetTenderBidsList(tenderId int) ([]internal.Bid, error) {
+func (s *Storage) GetTenderBidsList(ctx context.Context, v internal.Viewer, tenderId uuid.UUID, page internal.Page) ([]internal.Bid, error) {
 	const op = "storage.postgres.GetTenderBidsList"
 
-	_, err := s.CheckTenderExist(tenderId)
+	t, err := s.GetTender(ctx, tenderId)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	stmt, err := s.db.Prepare(`
-		SELECT t.id, b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version
+	err = s.checkTenderVisible(ctx, v, t)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		SELECT t.id, b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version,
+		       t.created_at, t.updated_at, COALESCE(t.last_edited_by, '')
 		FROM tender_bid AS t JOIN bid AS b ON t.bid_id = b.id
 		JOIN status as s ON b.status_id = s.id
-		WHERE b.tender_id = $1
+		WHERE b.tender_id = $2 AND `+bidVisible+`
+		ORDER BY b.name, t.id
+		LIMIT $3 OFFSET $4
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
@@ -830,14 +1191,15 @@ func (s *Storage) GetTenderBidsList(tenderId int) ([]internal.Bid, error) {
 
 	bids := make([]internal.Bid, 0)
 
-	rows, err := stmt.Query(tenderId)
+	rows, err := stmt.QueryContext(ctx, v.Username, tenderId, page.Limit, page.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var b internal.Bid
-		err = rows.Scan(&b.Id, &b.Name, &b.Description, &b.Status, &b.TenderId, &b.OrganizationId, &b.CreatorUsername, &b.Version)
+		err = rows.Scan(&b.Id, &b.Name, &b.Description, &b.Status, &b.TenderId, &b.OrganizationId, &b.CreatorUsername, &b.Version, &b.CreatedAt, &b.UpdatedAt, &b.LastEditedBy)
 		if err != nil {
 			return nil, fmt.Errorf("%s %w", op, err)
 		}
@@ -850,10 +1212,10 @@ func (s *Storage) GetTenderBidsList(tenderId int) ([]internal.Bid, error) {
 	return bids, nil
 }
 
-func (s *Storage) CheckTenderPublished(id int) (bool, error) {
+func (s *Storage) CheckTenderPublished(ctx context.Context, id uuid.UUID) (bool, error) {
 	const op = "storage.postgres.CheckTenderPublished"
 
-	stmt, err := s.db.Prepare(`
+	stmt, err := s.prepare(ctx, `
 		SELECT r.id
 		FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
 		JOIN status AS s ON t.status_id = s.id
@@ -863,9 +1225,9 @@ func (s *Storage) CheckTenderPublished(id int) (bool, error) {
 		return false, fmt.Errorf("%s %w", op, err)
 	}
 
-	var res int
+	var res uuid.UUID
 
-	err = stmt.QueryRow(id).Scan(&res)
+	err = stmt.QueryRowContext(ctx, id).Scan(&res)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, storage.ErrTenderNotPublished
@@ -877,10 +1239,10 @@ func (s *Storage) CheckTenderPublished(id int) (bool, error) {
 	return true, nil
 }
 
-func (s *Storage) CheckBidPublished(bidId int) (bool, error) {
+func (s *Storage) CheckBidPublished(ctx context.Context, bidId uuid.UUID) (bool, error) {
 	const op = "storage.postgres.CheckBidPublished"
 
-	stmt, err := s.db.Prepare(`
+	stmt, err := s.prepare(ctx, `
 		SELECT t.id
 		FROM tender_bid AS t JOIN bid AS b ON t.bid_id = b.id
 		JOIN status AS s ON b.status_id = s.id
@@ -890,9 +1252,9 @@ func (s *Storage) CheckBidPublished(bidId int) (bool, error) {
 		return false, fmt.Errorf("%s %w", op, err)
 	}
 
-	var res int
+	var res uuid.UUID
 
-	err = stmt.QueryRow(bidId).Scan(&res)
+	err = stmt.QueryRowContext(ctx, bidId).Scan(&res)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, storage.ErrBidNotPublished
@@ -904,17 +1266,17 @@ func (s *Storage) CheckBidPublished(bidId int) (bool, error) {
 	return true, nil
 }
 
-func (s *Storage) CheckBidExist(bidId int) (bool, error) {
+func (s *Storage) CheckBidExist(ctx context.Context, bidId uuid.UUID) (bool, error) {
 	const op = "storage.postgres.CheckBidExist"
 
-	stmt, err := s.db.Prepare("SELECT id FROM tender_bid WHERE id = $1")
+	stmt, err := s.prepare(ctx, "SELECT id FROM tender_bid WHERE id = $1")
 	if err != nil {
 		return false, fmt.Errorf("%s %w", op, err)
 	}
 
-	var res int
+	var res uuid.UUID
 
-	err = stmt.QueryRow(bidId).Scan(&res)
+	err = stmt.QueryRowContext(ctx, bidId).Scan(&res)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, storage.ErrBidNotFound
@@ -926,94 +1288,433 @@ func (s *Storage) CheckBidExist(bidId int) (bool, error) {
 	return true, nil
 }
 
-func (s *Storage) GetBidTenderId(bidId int) (int, error) {
+func (s *Storage) GetBidTenderId(ctx context.Context, bidId uuid.UUID) (uuid.UUID, error) {
 	const op = "storage.postgres.GetBidTenderId"
 
-	stmt, err := s.db.Prepare(`
+	stmt, err := s.prepare(ctx, `
 		SELECT t.tender_id
 		FROM tender_bid AS t JOIN bid AS b on t.bid_id = b.id
 		WHERE t.id = $1
 	`)
 	if err != nil {
-		return 0, fmt.Errorf("%s %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	var tenderId int
+	var tenderId uuid.UUID
 
-	err = stmt.QueryRow(bidId).Scan(&tenderId)
+	err = stmt.QueryRowContext(ctx, bidId).Scan(&tenderId)
 	if err != nil {
-		return 0, fmt.Errorf("%s %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s %w", op, err)
 	}
 
 	return tenderId, nil
 }
 
-func (s *Storage) SubmitBid(bidId int, orgUsername string) error {
-	const op = "storage.postgres.SubmitBid"
+// CheckTenderResp reports whether username is a responsible
+// of the organization that owns the tender.
+func (s *Storage) CheckTenderResp(ctx context.Context, tenderId uuid.UUID, username string) error {
+	const op = "storage.postgres.CheckTenderResp"
 
-	_, err := s.CheckBidExist(bidId)
+	stmt, err := s.prepare(ctx, `
+		SELECT r.id
+		FROM organization_responsible AS r JOIN employee AS e ON r.user_id = e.id
+		WHERE e.username = $1
+		  AND r.organization_id = (SELECT t.organization_id
+								   FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
+								   WHERE r.id = $2)
+	`)
 	if err != nil {
 		return fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = s.CheckBidPublished(bidId)
+	var orgId uuid.UUID
+
+	err = stmt.QueryRowContext(ctx, username, tenderId).Scan(&orgId)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return s.respNotFound(ctx, username)
+		}
+
 		return fmt.Errorf("%s %w", op, err)
 	}
 
-	tenderId, err := s.GetBidTenderId(bidId)
+	return nil
+}
+
+func (s *Storage) GetTenderRespCount(ctx context.Context, tenderId uuid.UUID) (int, error) {
+	const op = "storage.postgres.GetTenderRespCount"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT COUNT(*)
+		FROM organization_responsible
+		WHERE organization_id = (SELECT t.organization_id
+								 FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
+								 WHERE r.id = $1)
+	`)
 	if err != nil {
-		return fmt.Errorf("%s %w", op, err)
+		return 0, fmt.Errorf("%s %w", op, err)
 	}
 
-	_, err = s.CheckTenderPublished(tenderId)
+	var count int
+
+	err = stmt.QueryRowContext(ctx, tenderId).Scan(&count)
 	if err != nil {
-		return fmt.Errorf("%s %w", op, err)
+		return 0, fmt.Errorf("%s %w", op, err)
 	}
 
-	checkOrg, err := s.db.Prepare(`
-		SELECT r.id
-		FROM organization_responsible AS r JOIN employee AS e ON r.user_id = e.id
-		WHERE e.username = $1
-		  AND r.organization_id = (SELECT t.organization_id
-								   FROM organization_responsible_tender AS r JOIN tender AS t ON r.tender_id = t.id
-								   WHERE r.id = $2)
+	return count, nil
+}
+
+// GetBidApprovalsCount counts the approvals of the bid made by current
+// responsibles of orgId. Decisions of those who have left it do not count.
+func (s *Storage) GetBidApprovalsCount(ctx context.Context, bidId, orgId uuid.UUID) (int, error) {
+	const op = "storage.postgres.GetBidApprovalsCount"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT COUNT(*)
+		FROM bid_decisions AS d
+		JOIN employee AS e ON d.username = e.username
+		JOIN organization_responsible AS r ON r.user_id = e.id
+		WHERE d.tender_bid_id = $1 AND d.decision = $2 AND r.organization_id = $3
 	`)
 	if err != nil {
-		return fmt.Errorf("%s %w", op, err)
+		return 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	var count int
+
+	err = stmt.QueryRowContext(ctx, bidId, internal.DecisionApproved, orgId).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	return count, nil
+}
+
+func (s *Storage) SubmitBid(ctx context.Context, bidId uuid.UUID, decision, orgUsername string) (internal.Bid, error) {
+	var submitted internal.Bid
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		submitted, err = tx.submitBid(ctx, bidId, decision, orgUsername)
+		return err
+	})
+
+	return submitted, err
+}
+
+func (s *Storage) submitBid(ctx context.Context, bidId uuid.UUID, decision, orgUsername string) (internal.Bid, error) {
+	const op = "storage.postgres.SubmitBid"
+
+	_, err := s.CheckBidExist(ctx, bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	tenderId, err := s.GetBidTenderId(ctx, bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	// The tender is locked before the bid so that concurrent decisions
+	// on any of its bids are counted one by one.
+	err = s.lockTender(ctx, tenderId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.lockBid(ctx, bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = s.CheckBidPublished(ctx, bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.checkTenderDecidable(ctx, tenderId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.CheckTenderResp(ctx, tenderId, orgUsername)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	before, err := s.GetBid(ctx, bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	tender, err := s.GetTender(ctx, tenderId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	decisionEntry, err := s.prepare(ctx, `
+		INSERT INTO bid_decisions(tender_bid_id, username, decision)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tender_bid_id, username) DO UPDATE SET decision = EXCLUDED.decision
+	`)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	_, err = decisionEntry.ExecContext(ctx, bidId, orgUsername, decision)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if decision == internal.DecisionRejected {
+		err = s.RejectBid(ctx, bidId)
+		if err != nil {
+			return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		}
+
+		_, err = s.touchBid(ctx, bidId, orgUsername)
+		if err != nil {
+			return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		}
+
+		return s.recordDecision(ctx, decision, orgUsername, tender, before)
+	}
+
+	approvals, err := s.GetBidApprovalsCount(ctx, bidId, tender.OrganizationId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	responsibles, err := s.GetTenderRespCount(ctx, tenderId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if approvals >= internal.DecisionQuorum(responsibles) {
+		err = s.ApproveBid(ctx, bidId)
+		if err != nil {
+			return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		}
+
+		_, err = s.touchBid(ctx, bidId, orgUsername)
+		if err != nil {
+			return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		}
+
+		// The approval settles a tender closed by its deadline too.
+		err = s.setClosedByDeadline(ctx, tenderId, false)
+		if err != nil {
+			return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+		}
+
+		if tender.Status == internal.TenderPublished {
+			err = s.closeApprovedTender(ctx, orgUsername, tender)
+			if err != nil {
+				return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+			}
+		}
+	}
+
+	return s.recordDecision(ctx, decision, orgUsername, tender, before)
+}
+
+// closeApprovedTender closes the tender once one of its bids is approved.
+func (s *Storage) closeApprovedTender(ctx context.Context, orgUsername string, tender internal.Tender) error {
+	err := s.CloseTender(ctx, tender.Id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.touchTender(ctx, tender.Id, orgUsername)
+	if err != nil {
+		return err
+	}
+
+	closed, err := s.GetTender(ctx, tender.Id)
+	if err != nil {
+		return err
+	}
+
+	return s.recordAudit(ctx, storage.TenderEvent(internal.ActionClose, orgUsername, &tender, closed))
+}
+
+// recordDecision records the decision on behalf of the tender organization
+// and returns the bid as it is after it.
+func (s *Storage) recordDecision(ctx context.Context, decision, orgUsername string, tender internal.Tender, before internal.Bid) (internal.Bid, error) {
+	const op = "storage.postgres.recordDecision"
+
+	after, err := s.GetBid(ctx, before.Id)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.BidEvent(internal.DecisionAction(decision, after.Status), orgUsername, tender.OrganizationId, &before, after))
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return after, nil
+}
+
+func (s *Storage) SubmitBidFeedback(ctx context.Context, bidId uuid.UUID, feedback, username string) (internal.Bid, error) {
+	var reviewed internal.Bid
+
+	err := s.withTx(ctx, func(tx *Storage) error {
+		var err error
+		reviewed, err = tx.submitBidFeedback(ctx, bidId, feedback, username)
+		return err
+	})
+
+	return reviewed, err
+}
+
+func (s *Storage) submitBidFeedback(ctx context.Context, bidId uuid.UUID, feedback, username string) (internal.Bid, error) {
+	const op = "storage.postgres.SubmitBidFeedback"
+
+	b, err := s.GetBid(ctx, bidId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.CheckTenderResp(ctx, b.TenderId, username)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	// Drafts are not shown to the tender organization yet.
+	if b.Status == internal.BidCreated {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, storage.ErrBidNotPublished)
+	}
+
+	feedbackEntry, err := s.prepare(ctx, `
+		INSERT INTO bid_feedback(tender_bid_id, username, description)
+		VALUES ($1, $2, $3) RETURNING id, created_at
+	`)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	review := internal.Review{Description: feedback}
+
+	err = feedbackEntry.QueryRowContext(ctx, bidId, username, feedback).Scan(&review.Id, &review.CreatedAt)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	tender, err := s.GetTender(ctx, b.TenderId)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.recordAudit(ctx, storage.Event{
+		EntityType:     internal.EntityBid,
+		EntityId:       bidId,
+		OrganizationId: tender.OrganizationId,
+		Action:         internal.ActionFeedback,
+		Actor:          username,
+		After:          review,
+	})
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return b, nil
+}
+
+func (s *Storage) CheckAuthorBidExist(ctx context.Context, tenderId uuid.UUID, authorUsername string) (bool, error) {
+	const op = "storage.postgres.CheckAuthorBidExist"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT t.id
+		FROM tender_bid AS t JOIN bid AS b ON t.bid_id = b.id
+		WHERE t.tender_id = $1 AND b.creator_username = $2
+		LIMIT 1
+	`)
+	if err != nil {
+		return false, fmt.Errorf("%s %w", op, err)
 	}
 
-	var orgId int
+	var res uuid.UUID
 
-	err = checkOrg.QueryRow(orgUsername, tenderId).Scan(&orgId)
+	err = stmt.QueryRowContext(ctx, tenderId, authorUsername).Scan(&res)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return storage.ErrOrgRespNotFound
+			return false, storage.ErrBidNotFound
 		}
 
-		return fmt.Errorf("%s %w", op, err)
+		return false, fmt.Errorf("%s %w", op, err)
 	}
 
-	err = s.CancelBid(bidId)
+	return true, nil
+}
+
+// GetBidReviews returns feedback left on every bid of the author.
+// The requester must be responsible for the tender the author bid on.
+func (s *Storage) GetBidReviews(ctx context.Context, tenderId uuid.UUID, authorUsername, requesterUsername string, page internal.Page) ([]internal.Review, error) {
+	const op = "storage.postgres.GetBidReviews"
+
+	_, err := s.CheckTenderExist(ctx, tenderId)
 	if err != nil {
-		return fmt.Errorf("%s %w", op, err)
+		return nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	err = s.CloseTender(tenderId)
+	err = s.CheckTenderResp(ctx, tenderId, requesterUsername)
 	if err != nil {
-		return fmt.Errorf("%s %w", op, err)
+		return nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	return nil
+	_, err = s.CheckAuthorBidExist(ctx, tenderId, authorUsername)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		SELECT f.id, f.description, f.created_at
+		FROM bid_feedback AS f JOIN tender_bid AS t ON f.tender_bid_id = t.id
+		JOIN bid AS b ON t.bid_id = b.id
+		WHERE b.creator_username = $1
+		ORDER BY f.created_at DESC, f.id DESC
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	reviews := make([]internal.Review, 0)
+
+	rows, err := stmt.QueryContext(ctx, authorUsername, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r internal.Review
+		err = rows.Scan(&r.Id, &r.Description, &r.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+		reviews = append(reviews, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return reviews, nil
 }
 
-func (s *Storage) GetBidsList() ([]internal.Bid, error) {
+func (s *Storage) GetBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error) {
 	const op = "storage.postgres.GetBidsList"
 
-	stmt, err := s.db.Prepare(`
-		SELECT t.id, b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version
+	stmt, err := s.prepare(ctx, `
+		SELECT t.id, b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version,
+		       t.created_at, t.updated_at, COALESCE(t.last_edited_by, '')
 		FROM tender_bid AS t JOIN bid AS b ON t.bid_id = b.id
 		JOIN status as s ON b.status_id = s.id
+		WHERE `+bidVisible+`
+		ORDER BY b.name, t.id
+		LIMIT $2 OFFSET $3
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
@@ -1021,14 +1722,15 @@ func (s *Storage) GetBidsList() ([]internal.Bid, error) {
 
 	bids := make([]internal.Bid, 0)
 
-	rows, err := stmt.Query()
+	rows, err := stmt.QueryContext(ctx, v.Username, page.Limit, page.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var b internal.Bid
-		err = rows.Scan(&b.Id, &b.Name, &b.Description, &b.Status, &b.TenderId, &b.OrganizationId, &b.CreatorUsername, &b.Version)
+		err = rows.Scan(&b.Id, &b.Name, &b.Description, &b.Status, &b.TenderId, &b.OrganizationId, &b.CreatorUsername, &b.Version, &b.CreatedAt, &b.UpdatedAt, &b.LastEditedBy)
 		if err != nil {
 			return nil, fmt.Errorf("%s %w", op, err)
 		}
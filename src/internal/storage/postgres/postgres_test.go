@@ -0,0 +1,202 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/config"
+	"tender-app-backend/src/internal/storage/postgres"
+	"tender-app-backend/src/internal/storage/storagetest"
+	"testing"
+)
+
+// connStr points to the database the tests run against. It is taken from
+// TEST_DATABASE_URL or, when that is unset, from a throwaway server started
+// with the initdb and pg_ctl binaries found in PATH. Tests are skipped only
+// when neither is available: a database that is set but does not work fails.
+var (
+	connStr    string
+	skipReason string
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	connStr = os.Getenv("TEST_DATABASE_URL")
+
+	if connStr == "" {
+		conn, stop, err := startPostgres()
+		if err != nil {
+			skipReason = "no postgres for tests, set TEST_DATABASE_URL: " + err.Error()
+		} else {
+			defer stop()
+			connStr = conn
+		}
+	}
+
+	return m.Run()
+}
+
+// startPostgres initializes a cluster in a temporary directory and starts it
+// listening on a unix socket only. stop shuts the server down and removes it.
+func startPostgres() (conn string, stop func(), err error) {
+	for _, bin := range []string{"initdb", "pg_ctl"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			return "", nil, err
+		}
+	}
+	if os.Geteuid() == 0 {
+		return "", nil, errors.New("postgres refuses to run as root, set TEST_DATABASE_URL instead")
+	}
+
+	dir, err := os.MkdirTemp("", "tender-pg")
+	if err != nil {
+		return "", nil, err
+	}
+	data := filepath.Join(dir, "data")
+
+	out, err := exec.Command("initdb", "-D", data, "-U", "postgres", "-A", "trust", "--no-sync").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("initdb: %w: %s", err, out)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	opts := fmt.Sprintf("-p %d -k %s -c listen_addresses='' -c fsync=off", port, dir)
+	out, err = exec.Command("pg_ctl", "-D", data, "-l", filepath.Join(dir, "log"), "-o", opts, "-w", "start").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("pg_ctl start: %w: %s", err, out)
+	}
+
+	stop = func() {
+		_ = exec.Command("pg_ctl", "-D", data, "-m", "immediate", "stop").Run()
+		os.RemoveAll(dir)
+	}
+
+	return fmt.Sprintf("host=%s port=%d user=postgres dbname=postgres sslmode=disable", dir, port), stop, nil
+}
+
+// freePort picks a port number for the server socket name.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func TestStorage(t *testing.T) {
+	db, s := open(t)
+	t.Cleanup(func() { s.Close() })
+
+	storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+		truncate(t, db)
+		return s
+	})
+}
+
+func TestClose(t *testing.T) {
+	db, s := open(t)
+	truncate(t, db)
+
+	// Twice, so the second call runs the cached statement.
+	for range 2 {
+		if _, err := s.GetEmployeesList(context.Background(), internal.Page{Limit: 5}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	if _, err := s.GetEmployeesList(context.Background(), internal.Page{Limit: 5}); err == nil {
+		t.Fatal("GetEmployeesList after Close succeeded")
+	}
+}
+
+// open creates the tables owned by other services and connects a Storage,
+// which migrates the rest. db is a separate connection for test setup.
+func open(t *testing.T) (*sql.DB, *postgres.Storage) {
+	t.Helper()
+
+	needPostgres(t)
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	schema, err := os.ReadFile(filepath.Join("testdata", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec(string(schema)); err != nil {
+		t.Fatalf("create employee and organization tables: %v", err)
+	}
+
+	s, err := postgres.New(&config.Config{Postgres: config.Postgres{ConnURL: connStr}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, s
+}
+
+// needPostgres skips the test when there is no database to run it against.
+func needPostgres(t *testing.T) {
+	t.Helper()
+
+	if connStr == "" {
+		t.Skip(skipReason)
+	}
+}
+
+// truncate empties every table except the ones filled by migrations.
+func truncate(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	rows, err := db.Query(`
+		SELECT tablename FROM pg_tables
+		WHERE schemaname = 'public' AND tablename NOT IN ('status', 'schema_migrations')
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			t.Fatal(err)
+		}
+		tables = append(tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec("TRUNCATE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+)
+
+// GetTenderRevisions lists who made each version of the tender and when,
+// oldest first.
+func (s *Storage) GetTenderRevisions(ctx context.Context, tenderId uuid.UUID, page internal.Page) ([]internal.Revision, error) {
+	const op = "storage.postgres.GetTenderRevisions"
+
+	_, err := s.CheckTenderExist(ctx, tenderId)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		SELECT tender_version, COALESCE(author, ''), created_at
+		FROM tender_versions
+		WHERE org_resp_tender_id = $1
+		ORDER BY tender_version
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	revisions, err := scanRevisions(ctx, stmt, tenderId, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return revisions, nil
+}
+
+// GetTenderRevision returns the tender as it was at version, last edited
+// by the author of that version.
+func (s *Storage) GetTenderRevision(ctx context.Context, tenderId uuid.UUID, version int) (internal.Tender, error) {
+	const op = "storage.postgres.GetTenderRevision"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT t.name, t.description, t.service_type, s.status_type, t.organization_id, t.creator_username, t.version,
+		       r.created_at, v.created_at, COALESCE(v.author, ''), t.bid_deadline
+		FROM tender_versions AS v JOIN tender AS t ON t.id = v.tender_id
+		JOIN organization_responsible_tender AS r ON r.id = v.org_resp_tender_id
+		JOIN status AS s ON t.status_id = s.id
+		WHERE v.org_resp_tender_id = $1 AND v.tender_version = $2
+	`)
+	if err != nil {
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	t := internal.Tender{Id: tenderId}
+
+	err = stmt.QueryRowContext(ctx, tenderId, version).Scan(&t.Name, &t.Description, &t.ServiceType, &t.Status, &t.OrganizationId, &t.CreatorUsername, &t.Version, &t.CreatedAt, &t.UpdatedAt, &t.LastEditedBy, &t.BidDeadline)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.Tender{}, fmt.Errorf("%s %w", op, s.missingTenderRevision(ctx, tenderId))
+		}
+
+		return internal.Tender{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return t, nil
+}
+
+// GetBidRevisions lists who made each version of the bid and when,
+// oldest first.
+func (s *Storage) GetBidRevisions(ctx context.Context, bidId uuid.UUID, page internal.Page) ([]internal.Revision, error) {
+	const op = "storage.postgres.GetBidRevisions"
+
+	_, err := s.CheckBidExist(ctx, bidId)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	stmt, err := s.prepare(ctx, `
+		SELECT bid_version, COALESCE(author, ''), created_at
+		FROM bid_versions
+		WHERE tender_bid_id = $1
+		ORDER BY bid_version
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	revisions, err := scanRevisions(ctx, stmt, bidId, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return revisions, nil
+}
+
+// GetBidRevision returns the bid as it was at version, last edited
+// by the author of that version.
+func (s *Storage) GetBidRevision(ctx context.Context, bidId uuid.UUID, version int) (internal.Bid, error) {
+	const op = "storage.postgres.GetBidRevision"
+
+	stmt, err := s.prepare(ctx, `
+		SELECT b.name, b.description, s.status_type, b.tender_id, b.organization_id, b.creator_username, b.version,
+		       t.created_at, v.created_at, COALESCE(v.author, '')
+		FROM bid_versions AS v JOIN bid AS b ON b.id = v.bid_id
+		JOIN tender_bid AS t ON t.id = v.tender_bid_id
+		JOIN status AS s ON b.status_id = s.id
+		WHERE v.tender_bid_id = $1 AND v.bid_version = $2
+	`)
+	if err != nil {
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	b := internal.Bid{Id: bidId}
+
+	err = stmt.QueryRowContext(ctx, bidId, version).Scan(&b.Name, &b.Description, &b.Status, &b.TenderId, &b.OrganizationId, &b.CreatorUsername, &b.Version, &b.CreatedAt, &b.UpdatedAt, &b.LastEditedBy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.Bid{}, fmt.Errorf("%s %w", op, s.missingBidRevision(ctx, bidId))
+		}
+
+		return internal.Bid{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return b, nil
+}
+
+// missingTenderRevision tells a missing tender from a missing version of it.
+func (s *Storage) missingTenderRevision(ctx context.Context, tenderId uuid.UUID) error {
+	if _, err := s.GetTender(ctx, tenderId); err != nil {
+		return err
+	}
+
+	return storage.ErrVersionNotFound
+}
+
+// missingBidRevision tells a missing bid from a missing version of it.
+func (s *Storage) missingBidRevision(ctx context.Context, bidId uuid.UUID) error {
+	if _, err := s.GetBid(ctx, bidId); err != nil {
+		return err
+	}
+
+	return storage.ErrVersionNotFound
+}
+
+func scanRevisions(ctx context.Context, stmt *sql.Stmt, args ...any) ([]internal.Revision, error) {
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]internal.Revision, 0)
+	for rows.Next() {
+		var r internal.Revision
+		if err := rows.Scan(&r.Version, &r.Author, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, r)
+	}
+
+	return revisions, rows.Err()
+}
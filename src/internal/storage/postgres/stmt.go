@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// errStorageClosed is returned by queries made after Close.
+var errStorageClosed = errors.New("storage is closed")
+
+// stmtCache keeps one prepared statement per query text. database/sql
+// prepares a statement on each pooled connection the first time it runs
+// there, so every query costs a round trip to prepare once per connection
+// rather than once per call.
+type stmtCache struct {
+	db *sql.DB
+
+	mu     sync.RWMutex
+	stmts  map[string]*sql.Stmt
+	closed bool
+}
+
+func newStmtCache(db *sql.DB) *stmtCache {
+	return &stmtCache{db: db, stmts: make(map[string]*sql.Stmt)}
+}
+
+// get returns the statement for query, preparing it on first use.
+func (c *stmtCache) get(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.mu.RLock()
+	stmt, ok := c.stmts[query]
+	closed := c.closed
+	c.mu.RUnlock()
+
+	if ok {
+		return stmt, nil
+	}
+	if closed {
+		return nil, errStorageClosed
+	}
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		stmt.Close()
+		return nil, errStorageClosed
+	}
+
+	// Another call may have prepared the same query meanwhile.
+	if cached, ok := c.stmts[query]; ok {
+		stmt.Close()
+		return cached, nil
+	}
+
+	c.stmts[query] = stmt
+
+	return stmt, nil
+}
+
+// close closes every cached statement, later calls to get fail.
+func (c *stmtCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+	for _, stmt := range c.stmts {
+		errs = append(errs, stmt.Close())
+	}
+
+	c.stmts = nil
+	c.closed = true
+
+	return errors.Join(errs...)
+}
+
+// prepare returns the cached statement for query. Inside a transaction
+// the statement is bound to it and released when the transaction ends.
+func (s *Storage) prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	const op = "storage.postgres.prepare"
+
+	stmt, err := s.stmts.get(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	if s.tx != nil {
+		return s.tx.StmtContext(ctx, stmt), nil
+	}
+
+	return stmt, nil
+}
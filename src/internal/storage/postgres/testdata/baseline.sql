@@ -0,0 +1,86 @@
+-- The database the service ran against before migrations: employee and
+-- organization tables keyed by integers, which the tables the service
+-- created at startup reference.
+
+CREATE TABLE employee (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) UNIQUE NOT NULL,
+    first_name VARCHAR(50),
+    last_name VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DO $$ BEGIN
+    CREATE TYPE organization_type AS ENUM ('IE', 'LLC', 'JSC');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE TABLE organization (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    type organization_type,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE organization_responsible (
+    id SERIAL PRIMARY KEY,
+    organization_id INT REFERENCES organization(id) ON DELETE CASCADE,
+    user_id INT REFERENCES employee(id) ON DELETE CASCADE
+);
+
+CREATE TABLE status (
+    id INT PRIMARY KEY,
+    status_type VARCHAR(20)
+);
+
+CREATE TABLE tender(
+    id serial PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    service_type VARCHAR(50),
+    status_id INT REFERENCES status(id) ON DELETE CASCADE,
+    organization_id INT REFERENCES organization(id) ON DELETE CASCADE,
+    creator_username VARCHAR(50) NOT NULL,
+    version INT NOT NULL
+);
+
+CREATE TABLE organization_responsible_tender(
+    id SERIAL PRIMARY KEY,
+    org_resp_id INT REFERENCES organization_responsible(id) ON DELETE CASCADE,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE
+);
+
+CREATE TABLE bid(
+    id serial PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    status_id INT REFERENCES status(id) ON DELETE CASCADE,
+    tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
+    organization_id INT REFERENCES organization(id) ON DELETE CASCADE,
+    creator_username VARCHAR(50) NOT NULL,
+    version INT NOT NULL
+);
+
+CREATE TABLE tender_bid(
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
+    bid_id INT REFERENCES bid(id) ON DELETE CASCADE
+);
+
+CREATE TABLE tender_versions(
+    id SERIAL PRIMARY KEY,
+    org_resp_tender_id INT REFERENCES organization_responsible_tender(id) ON DELETE CASCADE,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE,
+    tender_version INT
+);
+
+CREATE TABLE bid_versions(
+    id SERIAL PRIMARY KEY,
+    tender_bid_id INT REFERENCES tender_bid(id) ON DELETE CASCADE,
+    bid_id INT REFERENCES bid(id) ON DELETE CASCADE,
+    bid_version INT
+);
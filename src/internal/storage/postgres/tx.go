@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal/storage"
+)
+
+// withTx runs fn as a single unit of work. fn gets a Storage bound to the
+// transaction, which is committed if fn succeeds and rolled back otherwise.
+// Calls made on a Storage that is already bound to a transaction join it.
+func (s *Storage) withTx(ctx context.Context, fn func(tx *Storage) error) error {
+	const op = "storage.postgres.withTx"
+
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	err = fn(&Storage{db: s.db, stmts: s.stmts, tx: tx})
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("%s %w", op, rbErr))
+		}
+
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// lockTender locks the tender row until the end of the transaction,
+// so concurrent changes of the same tender are applied one by one.
+func (s *Storage) lockTender(ctx context.Context, tenderId uuid.UUID) error {
+	const op = "storage.postgres.lockTender"
+
+	stmt, err := s.prepare(ctx, "SELECT id FROM organization_responsible_tender WHERE id = $1 FOR UPDATE")
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	var res uuid.UUID
+
+	err = stmt.QueryRowContext(ctx, tenderId).Scan(&res)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrTenderNotFound
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// lockBid locks the bid row until the end of the transaction,
+// so concurrent changes of the same bid are applied one by one.
+func (s *Storage) lockBid(ctx context.Context, bidId uuid.UUID) error {
+	const op = "storage.postgres.lockBid"
+
+	stmt, err := s.prepare(ctx, "SELECT id FROM tender_bid WHERE id = $1 FOR UPDATE")
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	var res uuid.UUID
+
+	err = stmt.QueryRowContext(ctx, bidId).Scan(&res)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrBidNotFound
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// lockOrganization locks the organization row until the end of the
+// transaction, so its responsibles change one by one.
+func (s *Storage) lockOrganization(ctx context.Context, orgId uuid.UUID) error {
+	const op = "storage.postgres.lockOrganization"
+
+	stmt, err := s.prepare(ctx, "SELECT id FROM organization WHERE id = $1 FOR UPDATE")
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	var res uuid.UUID
+
+	err = stmt.QueryRowContext(ctx, orgId).Scan(&res)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrOrganizationNotFound
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
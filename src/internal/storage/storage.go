@@ -1,13 +1,91 @@
 package storage
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"time"
+)
 
 var (
-	ErrNotFound           = errors.New("not found")
-	ErrAlreadyExists      = errors.New("already exists")
-	ErrOrgRespNotFound    = errors.New("organisation responsible employee not found")
-	ErrTenderNotFound     = errors.New("tndcreate with provided info not found")
-	ErrBidNotFound        = errors.New("bid with provided info not found")
-	ErrTenderNotPublished = errors.New("tndcreate not published")
-	ErrBidNotPublished    = errors.New("bid not published")
+	ErrNotFound             = errors.New("not found")
+	ErrAlreadyExists        = errors.New("already exists")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrOrgRespNotFound      = errors.New("organisation responsible employee not found")
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrLastResponsible      = errors.New("organization has no other responsible")
+	ErrOrganizationInUse    = errors.New("organization has tenders or bids")
+	ErrTenderNotFound       = errors.New("tender not found")
+	ErrBidNotFound          = errors.New("bid not found")
+	ErrTenderNotPublished   = errors.New("tender not published")
+	ErrBidNotPublished      = errors.New("bid not published")
+	ErrInvalidTransition    = errors.New("status transition not allowed")
+	ErrVersionConflict      = errors.New("version is not the current one")
+	ErrVersionNotFound      = errors.New("version not found")
+	ErrBidDeadlinePassed    = errors.New("tender bid deadline has passed")
 )
+
+// Storage is the full method set the http handlers rely on.
+//
+// Edits and rollbacks given a non-zero expected version fail with
+// ErrVersionConflict unless it is the current version. The username they
+// are given is recorded as the author of the new version.
+//
+// Bids of a tender past its bid deadline can not be created, edited, rolled
+// back or published: that fails with ErrBidDeadlinePassed.
+// CloseExpiredTenders closes published tenders whose deadline is not after
+// now on behalf of internal.SystemActor; replicas sharing a database may
+// call it at the same time. Bids of a tender closed this way can still be
+// decided on until one of them is approved.
+//
+// Every change of a tender or bid, including status changes, decisions and
+// feedback, is recorded in the audit log together with the change, and so
+// are changes of employees, organizations and responsibles made by actor.
+type Storage interface {
+	CreateEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error)
+	GetEmployee(ctx context.Context, id uuid.UUID) (internal.Employee, error)
+	GetEmployeeByUsername(ctx context.Context, username string) (internal.Employee, error)
+	GetEmployeesList(ctx context.Context, page internal.Page) ([]internal.Employee, error)
+	EditEmployee(ctx context.Context, e internal.Employee, actor string) (internal.Employee, error)
+	DeleteEmployee(ctx context.Context, id uuid.UUID, actor string) error
+
+	CreateOrganization(ctx context.Context, o internal.Organization, actor string, responsibles ...string) (internal.Organization, error)
+	GetOrganization(ctx context.Context, orgId uuid.UUID) (internal.Organization, error)
+	GetOrganizationsList(ctx context.Context, page internal.Page) ([]internal.Organization, error)
+	EditOrganization(ctx context.Context, o internal.Organization, actor string) (internal.Organization, error)
+	DeleteOrganization(ctx context.Context, orgId uuid.UUID, actor string) error
+	GetOrganizationResponsibles(ctx context.Context, orgId uuid.UUID, page internal.Page) ([]internal.Employee, error)
+	AddOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error
+	RemoveOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username, actor string) error
+	IsOrganizationResponsible(ctx context.Context, orgId uuid.UUID, username string) (bool, error)
+
+	CreateTender(ctx context.Context, t internal.Tender) (internal.Tender, error)
+	GetTender(ctx context.Context, tenderId uuid.UUID) (internal.Tender, error)
+	GetTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error)
+	GetUserTendersList(ctx context.Context, v internal.Viewer, serviceTypes []string, page internal.Page) ([]internal.Tender, error)
+	UpdateTenderStatus(ctx context.Context, tenderId uuid.UUID, status, username string) (internal.Tender, error)
+	EditTender(ctx context.Context, t internal.Tender, editId uuid.UUID, expected int, username string) (internal.Tender, error)
+	RollbackTender(ctx context.Context, tenderId uuid.UUID, version, expected int, username string) (internal.Tender, error)
+	CloseExpiredTenders(ctx context.Context, now time.Time) ([]internal.Tender, error)
+	GetTenderRevisions(ctx context.Context, tenderId uuid.UUID, page internal.Page) ([]internal.Revision, error)
+	GetTenderRevision(ctx context.Context, tenderId uuid.UUID, version int) (internal.Tender, error)
+
+	CreateBid(ctx context.Context, b internal.Bid) (internal.Bid, error)
+	GetBid(ctx context.Context, bidId uuid.UUID) (internal.Bid, error)
+	GetUserBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error)
+	GetTenderBidsList(ctx context.Context, v internal.Viewer, tenderId uuid.UUID, page internal.Page) ([]internal.Bid, error)
+	GetBidsList(ctx context.Context, v internal.Viewer, page internal.Page) ([]internal.Bid, error)
+	UpdateBidStatus(ctx context.Context, bidId uuid.UUID, status, username string) (internal.Bid, error)
+	EditBid(ctx context.Context, b internal.Bid, editId uuid.UUID, expected int, username string) (internal.Bid, error)
+	RollbackBid(ctx context.Context, bidId uuid.UUID, version, expected int, username string) (internal.Bid, error)
+	GetBidRevisions(ctx context.Context, bidId uuid.UUID, page internal.Page) ([]internal.Revision, error)
+	GetBidRevision(ctx context.Context, bidId uuid.UUID, version int) (internal.Bid, error)
+	SubmitBid(ctx context.Context, bidId uuid.UUID, decision, orgUsername string) (internal.Bid, error)
+	SubmitBidFeedback(ctx context.Context, bidId uuid.UUID, feedback, username string) (internal.Bid, error)
+	GetBidReviews(ctx context.Context, tenderId uuid.UUID, authorUsername, requesterUsername string, page internal.Page) ([]internal.Review, error)
+
+	GetAuditEvents(ctx context.Context, f internal.AuditFilter, page internal.Page) ([]internal.AuditEvent, error)
+
+	Close() error
+}
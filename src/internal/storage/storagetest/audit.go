@@ -0,0 +1,99 @@
+package storagetest
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5/middleware"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+	"time"
+)
+
+func testAuditEvents(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	_, err := f.s.EditTender(ctx, internal.Tender{Name: "Bridges"}, tender.Id, 0, bob)
+	wantNoErr(t, err)
+
+	_, err = f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, alice)
+	wantNoErr(t, err)
+
+	_, err = f.s.RollbackTender(ctx, tender.Id, 1, 0, alice)
+	wantNoErr(t, err)
+
+	// Refused changes leave nothing behind.
+	_, err = f.s.EditTender(ctx, internal.Tender{Name: "Tunnels"}, tender.Id, 1, bob)
+	wantErr(t, err, storage.ErrVersionConflict)
+
+	bid := f.publishedBid(t, tender.Id, "Asphalt")
+
+	_, err = f.s.SubmitBid(ctx, bid.Id, internal.DecisionRejected, bob)
+	wantNoErr(t, err)
+
+	reqCtx := context.WithValue(ctx, middleware.RequestIDKey, "host/abc-000001")
+
+	_, err = f.s.SubmitBidFeedback(reqCtx, bid.Id, "Too slow", alice)
+	wantNoErr(t, err)
+
+	customer := internal.AuditFilter{OrganizationId: f.customer}
+
+	events, err := f.s.GetAuditEvents(ctx, customer, allPage)
+	wantNoErr(t, err)
+	wantActions(t, events,
+		"bid feedback alice", "bid reject bob",
+		"tender rollback alice", "tender publish alice", "tender edit bob", "tender create alice",
+		"organization_responsible create alice", "organization_responsible create alice", "organization create alice")
+	wantEqual(t, "request id", events[0].RequestId, "host/abc-000001")
+	wantEqual(t, "no request id", events[1].RequestId, "")
+
+	created := events[5]
+	if len(created.Before) != 0 {
+		t.Fatalf("creation has a before snapshot: %s", created.Before)
+	}
+	wantEqual(t, "entity", created.EntityId, tender.Id)
+
+	var before, after internal.Tender
+	wantNoErr(t, json.Unmarshal(events[3].Before, &before))
+	wantNoErr(t, json.Unmarshal(events[3].After, &after))
+	wantEqual(t, "status before publishing", before.Status, internal.TenderCreated)
+	wantEqual(t, "status after publishing", after.Status, internal.TenderPublished)
+
+	var rejected internal.Bid
+	wantNoErr(t, json.Unmarshal(events[1].After, &rejected))
+	wantEqual(t, "rejected bid", rejected.Id, bid.Id)
+	wantEqual(t, "status after rejection", rejected.Status, internal.BidRejected)
+
+	events, err = f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.bidder}, allPage)
+	wantNoErr(t, err)
+	wantActions(t, events, "bid publish carol", "bid create carol",
+		"organization_responsible create carol", "organization_responsible create carol", "organization create carol")
+
+	events, err = f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.customer, EntityType: internal.EntityBid}, allPage)
+	wantNoErr(t, err)
+	wantActions(t, events, "bid feedback alice", "bid reject bob")
+
+	events, err = f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.customer, EntityId: tender.Id}, internal.Page{Limit: 2, Offset: 1})
+	wantNoErr(t, err)
+	wantActions(t, events, "tender publish alice", "tender edit bob")
+
+	events, err = f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.customer, From: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}, allPage)
+	wantNoErr(t, err)
+	wantEqual(t, "events since 2000", len(events), 9)
+
+	events, err = f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.customer, To: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}, allPage)
+	wantNoErr(t, err)
+	wantEqual(t, "events before 2000", len(events), 0)
+}
+
+// wantActions compares events by entity type, action and actor.
+func wantActions(t *testing.T, events []internal.AuditEvent, want ...string) {
+	t.Helper()
+
+	got := make([]string, 0, len(events))
+	for _, e := range events {
+		got = append(got, e.EntityType+" "+e.Action+" "+e.Actor)
+	}
+
+	wantNames(t, got, want...)
+}
@@ -0,0 +1,314 @@
+package storagetest
+
+import (
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func testCreateBid(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+
+	bid := f.bid(t, tender.Id, "Asphalt")
+
+	if bid.Id == uuid.Nil {
+		t.Fatal("created bid has no id")
+	}
+	wantEqual(t, "tender id", bid.TenderId, tender.Id)
+	wantEqual(t, "version", bid.Version, 1)
+	wantEqual(t, "status", bid.Status, internal.BidCreated)
+	wantEqual(t, "stored status", f.bidStatus(t, bid.Id), internal.BidCreated)
+
+	_, err := f.s.CreateBid(ctx, internal.Bid{Name: "Asphalt", TenderId: tender.Id, OrganizationId: f.bidder, CreatorUsername: erin})
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	_, err = f.s.CreateBid(ctx, internal.Bid{Name: "Asphalt", TenderId: tender.Id, OrganizationId: f.bidder, CreatorUsername: mallory})
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	_, err = f.s.CreateBid(ctx, internal.Bid{Name: "Asphalt", TenderId: uuid.New(), OrganizationId: f.bidder, CreatorUsername: carol})
+	wantErr(t, err, storage.ErrTenderNotFound)
+}
+
+func testEditBid(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	bid := f.bid(t, tender.Id, "Asphalt")
+
+	edited, err := f.s.EditBid(ctx, internal.Bid{Name: "Concrete"}, bid.Id, 0, carol)
+	wantNoErr(t, err)
+	wantEqual(t, "id", edited.Id, bid.Id)
+	wantEqual(t, "version", edited.Version, 2)
+	wantEqual(t, "name", edited.Name, "Concrete")
+	wantEqual(t, "description", edited.Description, bid.Description)
+	wantEqual(t, "tender id", edited.TenderId, tender.Id)
+
+	edited, err = f.s.EditBid(ctx, internal.Bid{Description: "Fast"}, bid.Id, 0, carol)
+	wantNoErr(t, err)
+	wantEqual(t, "version", edited.Version, 3)
+	wantEqual(t, "name", edited.Name, "Concrete")
+	wantEqual(t, "description", edited.Description, "Fast")
+
+	bids, err := f.s.GetUserBidsList(ctx, internal.Viewer{Username: carol}, allPage)
+	wantNoErr(t, err)
+	wantNames(t, bidNames(bids), "Concrete")
+	wantEqual(t, "listed version", bids[0].Version, 3)
+
+	_, err = f.s.EditBid(ctx, internal.Bid{Name: "Gravel"}, uuid.New(), 0, carol)
+	wantErr(t, err, storage.ErrBidNotFound)
+}
+
+func testRollbackBid(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	bid := f.bid(t, tender.Id, "Asphalt")
+
+	_, err := f.s.EditBid(ctx, internal.Bid{Name: "Concrete"}, bid.Id, 0, carol)
+	wantNoErr(t, err)
+
+	_, err = f.s.UpdateBidStatus(ctx, bid.Id, internal.BidPublished, carol)
+	wantNoErr(t, err)
+
+	rolledBack, err := f.s.RollbackBid(ctx, bid.Id, 1, 0, carol)
+	wantNoErr(t, err)
+	wantEqual(t, "id", rolledBack.Id, bid.Id)
+	wantEqual(t, "version", rolledBack.Version, 3)
+	wantEqual(t, "name", rolledBack.Name, "Asphalt")
+	wantEqual(t, "status", rolledBack.Status, internal.BidPublished)
+
+	_, err = f.s.RollbackBid(ctx, bid.Id, 10, 0, carol)
+	wantErr(t, err, storage.ErrBidNotFound)
+
+	_, err = f.s.RollbackBid(ctx, uuid.New(), 1, 0, carol)
+	wantErr(t, err, storage.ErrBidNotFound)
+}
+
+func testBidExpectedVersion(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	bid := f.bid(t, tender.Id, "Asphalt")
+
+	edited, err := f.s.EditBid(ctx, internal.Bid{Name: "Concrete"}, bid.Id, 1, carol)
+	wantNoErr(t, err)
+	wantEqual(t, "version", edited.Version, 2)
+
+	_, err = f.s.EditBid(ctx, internal.Bid{Name: "Gravel"}, bid.Id, 1, carol)
+	wantErr(t, err, storage.ErrVersionConflict)
+
+	_, err = f.s.RollbackBid(ctx, bid.Id, 1, 3, carol)
+	wantErr(t, err, storage.ErrVersionConflict)
+
+	rolledBack, err := f.s.RollbackBid(ctx, bid.Id, 1, 2, carol)
+	wantNoErr(t, err)
+	wantEqual(t, "version", rolledBack.Version, 3)
+	wantEqual(t, "name", rolledBack.Name, "Asphalt")
+}
+
+func testUpdateBidStatus(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	bid := f.bid(t, tender.Id, "Asphalt")
+
+	_, err := f.s.UpdateBidStatus(ctx, bid.Id, internal.BidPublished, alice)
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	_, err = f.s.UpdateBidStatus(ctx, bid.Id, internal.BidCanceled, carol)
+	wantErr(t, err, storage.ErrInvalidTransition)
+
+	// Responsibles of the author organization act on its bids.
+	updated, err := f.s.UpdateBidStatus(ctx, bid.Id, internal.BidPublished, dave)
+	wantNoErr(t, err)
+	wantEqual(t, "status", updated.Status, internal.BidPublished)
+	wantEqual(t, "stored status", f.bidStatus(t, bid.Id), internal.BidPublished)
+
+	// Outcomes are only reachable through SubmitBid.
+	_, err = f.s.UpdateBidStatus(ctx, bid.Id, internal.BidApproved, carol)
+	wantErr(t, err, storage.ErrInvalidTransition)
+
+	_, err = f.s.UpdateBidStatus(ctx, bid.Id, internal.BidCanceled, carol)
+	wantNoErr(t, err)
+
+	_, err = f.s.UpdateBidStatus(ctx, bid.Id, internal.BidPublished, carol)
+	wantErr(t, err, storage.ErrInvalidTransition)
+
+	_, err = f.s.UpdateBidStatus(ctx, uuid.New(), internal.BidPublished, carol)
+	wantErr(t, err, storage.ErrBidNotFound)
+}
+
+func testBidVisibility(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	draft := f.bid(t, tender.Id, "Asphalt")
+	published := f.publishedBid(t, tender.Id, "Concrete")
+
+	// A bid in CREATED is private to its organization.
+	err := f.check(authz.ViewBid, alice, authz.Bid(draft))
+	wantErr(t, err, authz.ErrDenied)
+
+	err = f.check(authz.ViewBid, dave, authz.Bid(draft))
+	wantNoErr(t, err)
+
+	err = f.check(authz.ViewBid, alice, authz.Bid(published))
+	wantNoErr(t, err)
+
+	err = f.check(authz.ViewBid, erin, authz.Bid(published))
+	wantErr(t, err, authz.ErrDenied)
+
+	_, err = f.s.GetBid(ctx, uuid.New())
+	wantErr(t, err, storage.ErrBidNotFound)
+
+	bids, err := f.s.GetTenderBidsList(ctx, internal.Viewer{Username: alice}, tender.Id, allPage)
+	wantNoErr(t, err)
+	wantNames(t, bidNames(bids), "Concrete")
+
+	bids, err = f.s.GetTenderBidsList(ctx, internal.Viewer{Username: dave}, tender.Id, internal.Page{Limit: 1, Offset: 1})
+	wantNoErr(t, err)
+	wantNames(t, bidNames(bids), "Concrete")
+
+	_, err = f.s.GetTenderBidsList(ctx, internal.Viewer{Username: alice}, uuid.New(), allPage)
+	wantErr(t, err, storage.ErrTenderNotFound)
+
+	bids, err = f.s.GetBidsList(ctx, internal.Viewer{Username: erin}, allPage)
+	wantNoErr(t, err)
+	wantNames(t, bidNames(bids))
+
+	bids, err = f.s.GetBidsList(ctx, internal.Viewer{Username: alice}, allPage)
+	wantNoErr(t, err)
+	wantNames(t, bidNames(bids), "Concrete")
+
+	bids, err = f.s.GetUserBidsList(ctx, internal.Viewer{Username: carol}, allPage)
+	wantNoErr(t, err)
+	wantNames(t, bidNames(bids), "Asphalt", "Concrete")
+
+	// A tender hidden from the viewer hides its bids too.
+	hidden := f.tender(t, "Bridges")
+
+	_, err = f.s.GetTenderBidsList(ctx, internal.Viewer{Username: carol}, hidden.Id, allPage)
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+}
+
+func testSubmitBid(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	draft := f.bid(t, tender.Id, "Asphalt")
+	bid := f.publishedBid(t, tender.Id, "Concrete")
+
+	_, err := f.s.SubmitBid(ctx, draft.Id, internal.DecisionApproved, alice)
+	wantErr(t, err, storage.ErrBidNotPublished)
+
+	_, err = f.s.SubmitBid(ctx, bid.Id, internal.DecisionApproved, carol)
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	_, err = f.s.SubmitBid(ctx, uuid.New(), internal.DecisionApproved, alice)
+	wantErr(t, err, storage.ErrBidNotFound)
+
+	// A single rejection is final.
+	rejected, err := f.s.SubmitBid(ctx, bid.Id, internal.DecisionRejected, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "status", rejected.Status, internal.BidRejected)
+	wantEqual(t, "tender status", f.tenderStatus(t, tender.Id), internal.TenderPublished)
+
+	_, err = f.s.SubmitBid(ctx, bid.Id, internal.DecisionApproved, bob)
+	wantErr(t, err, storage.ErrBidNotPublished)
+
+	draftTender := f.tender(t, "Bridges")
+	draftTenderBid := f.bid(t, draftTender.Id, "Steel")
+
+	_, err = f.s.UpdateBidStatus(ctx, draftTenderBid.Id, internal.BidPublished, carol)
+	wantNoErr(t, err)
+
+	_, err = f.s.SubmitBid(ctx, draftTenderBid.Id, internal.DecisionApproved, alice)
+	wantErr(t, err, storage.ErrTenderNotPublished)
+}
+
+func testSubmitBidQuorum(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	bid := f.publishedBid(t, tender.Id, "Asphalt")
+
+	// The customer has two responsibles, so both have to approve.
+	submitted, err := f.s.SubmitBid(ctx, bid.Id, internal.DecisionApproved, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "status", submitted.Status, internal.BidPublished)
+
+	// Deciding again replaces the previous decision instead of counting twice.
+	submitted, err = f.s.SubmitBid(ctx, bid.Id, internal.DecisionApproved, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "status", submitted.Status, internal.BidPublished)
+
+	submitted, err = f.s.SubmitBid(ctx, bid.Id, internal.DecisionApproved, bob)
+	wantNoErr(t, err)
+	wantEqual(t, "status", submitted.Status, internal.BidApproved)
+	wantEqual(t, "stored status", f.bidStatus(t, bid.Id), internal.BidApproved)
+	wantEqual(t, "tender status", f.tenderStatus(t, tender.Id), internal.TenderClosed)
+
+	events, err := f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.customer, EntityId: bid.Id}, allPage)
+	wantNoErr(t, err)
+	wantActions(t, events, "bid approve bob", "bid decision_recorded alice", "bid decision_recorded alice")
+}
+
+func testSubmitBidQuorumLeavers(t *testing.T, f *fixture) {
+	err := f.s.AddOrganizationResponsible(ctx, f.customer, erin, alice)
+	wantNoErr(t, err)
+
+	tender := f.publishedTender(t, "Roads")
+	bid := f.publishedBid(t, tender.Id, "Asphalt")
+
+	_, err = f.s.SubmitBid(ctx, bid.Id, internal.DecisionApproved, alice)
+	wantNoErr(t, err)
+
+	// Alice leaves, her approval no longer counts towards the two needed now.
+	err = f.s.RemoveOrganizationResponsible(ctx, f.customer, alice, alice)
+	wantNoErr(t, err)
+
+	submitted, err := f.s.SubmitBid(ctx, bid.Id, internal.DecisionApproved, bob)
+	wantNoErr(t, err)
+	wantEqual(t, "status", submitted.Status, internal.BidPublished)
+
+	submitted, err = f.s.SubmitBid(ctx, bid.Id, internal.DecisionApproved, erin)
+	wantNoErr(t, err)
+	wantEqual(t, "status", submitted.Status, internal.BidApproved)
+}
+
+func testBidFeedback(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	bid := f.publishedBid(t, tender.Id, "Asphalt")
+
+	_, err := f.s.SubmitBidFeedback(ctx, bid.Id, "too expensive", carol)
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	_, err = f.s.SubmitBidFeedback(ctx, bid.Id, "too expensive", mallory)
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	_, err = f.s.SubmitBidFeedback(ctx, uuid.New(), "too expensive", alice)
+	wantErr(t, err, storage.ErrBidNotFound)
+
+	// Drafts are hidden from the tender organization.
+	draft := f.bid(t, tender.Id, "Concrete")
+	_, err = f.s.SubmitBidFeedback(ctx, draft.Id, "too expensive", alice)
+	wantErr(t, err, storage.ErrBidNotPublished)
+
+	for _, feedback := range []string{"first", "second", "third"} {
+		fed, err := f.s.SubmitBidFeedback(ctx, bid.Id, feedback, alice)
+		wantNoErr(t, err)
+		wantEqual(t, "bid id", fed.Id, bid.Id)
+	}
+
+	reviews, err := f.s.GetBidReviews(ctx, tender.Id, carol, bob, allPage)
+	wantNoErr(t, err)
+
+	descriptions := make([]string, 0, len(reviews))
+	for _, r := range reviews {
+		descriptions = append(descriptions, r.Description)
+	}
+	wantNames(t, descriptions, "third", "second", "first")
+
+	reviews, err = f.s.GetBidReviews(ctx, tender.Id, carol, bob, internal.Page{Limit: 1, Offset: 1})
+	wantNoErr(t, err)
+	if len(reviews) != 1 || reviews[0].Description != "second" {
+		t.Fatalf("got reviews %v, want only the second one", reviews)
+	}
+
+	_, err = f.s.GetBidReviews(ctx, tender.Id, carol, dave, allPage)
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	_, err = f.s.GetBidReviews(ctx, tender.Id, dave, alice, allPage)
+	wantErr(t, err, storage.ErrBidNotFound)
+
+	_, err = f.s.GetBidReviews(ctx, uuid.New(), carol, alice, allPage)
+	wantErr(t, err, storage.ErrTenderNotFound)
+}
@@ -0,0 +1,181 @@
+package storagetest
+
+import (
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+	"time"
+)
+
+func testBidDeadline(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+
+	// Backends keep times to the microsecond at best.
+	future := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	past := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+
+	edited, err := f.s.EditTender(ctx, internal.Tender{BidDeadline: &future}, tender.Id, 0, alice)
+	wantNoErr(t, err)
+	wantDeadline(t, edited, &future)
+
+	got, err := f.s.GetTender(ctx, tender.Id)
+	wantNoErr(t, err)
+	wantDeadline(t, got, &future)
+
+	// Edits that do not mention the deadline keep it.
+	edited, err = f.s.EditTender(ctx, internal.Tender{Name: "Bridges"}, tender.Id, 0, alice)
+	wantNoErr(t, err)
+	wantDeadline(t, edited, &future)
+
+	bid := f.bid(t, tender.Id, "Asphalt")
+	published := f.publishedBid(t, tender.Id, "Concrete")
+
+	_, err = f.s.EditTender(ctx, internal.Tender{BidDeadline: &past}, tender.Id, 0, alice)
+	wantNoErr(t, err)
+
+	_, err = f.s.CreateBid(ctx, internal.Bid{Name: "Gravel", TenderId: tender.Id, OrganizationId: f.bidder, CreatorUsername: carol})
+	wantErr(t, err, storage.ErrBidDeadlinePassed)
+
+	_, err = f.s.EditBid(ctx, internal.Bid{Name: "Cheap asphalt"}, bid.Id, 0, carol)
+	wantErr(t, err, storage.ErrBidDeadlinePassed)
+
+	_, err = f.s.RollbackBid(ctx, published.Id, 1, 0, carol)
+	wantErr(t, err, storage.ErrBidDeadlinePassed)
+
+	_, err = f.s.UpdateBidStatus(ctx, bid.Id, internal.BidPublished, carol)
+	wantErr(t, err, storage.ErrBidDeadlinePassed)
+	wantEqual(t, "refused bid status", f.bidStatus(t, bid.Id), internal.BidCreated)
+
+	// Bids can still be withdrawn, decisions are covered by testDecideAfterDeadline.
+	_, err = f.s.UpdateBidStatus(ctx, published.Id, internal.BidCanceled, carol)
+	wantNoErr(t, err)
+
+	// Rolling back to a version without a deadline lifts it.
+	rolledBack, err := f.s.RollbackTender(ctx, tender.Id, 1, 0, alice)
+	wantNoErr(t, err)
+	wantDeadline(t, rolledBack, nil)
+
+	f.bid(t, tender.Id, "Gravel")
+}
+
+func testDecideAfterDeadline(t *testing.T, f *fixture) {
+	future := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	past := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+
+	tender := f.deadlineTender(t, "Roads", &future, true)
+	rejected := f.publishedBid(t, tender.Id, "Asphalt")
+	approved := f.publishedBid(t, tender.Id, "Concrete")
+	late := f.publishedBid(t, tender.Id, "Stone")
+
+	_, err := f.s.EditTender(ctx, internal.Tender{BidDeadline: &past}, tender.Id, 0, alice)
+	wantNoErr(t, err)
+
+	// The scheduler gets to the tender before its responsibles decide.
+	closed, err := f.s.CloseExpiredTenders(ctx, time.Now())
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(closed), "Roads")
+
+	decided, err := f.s.SubmitBid(ctx, rejected.Id, internal.DecisionRejected, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "rejected status", decided.Status, internal.BidRejected)
+
+	_, err = f.s.SubmitBid(ctx, approved.Id, internal.DecisionApproved, alice)
+	wantNoErr(t, err)
+
+	decided, err = f.s.SubmitBid(ctx, approved.Id, internal.DecisionApproved, bob)
+	wantNoErr(t, err)
+	wantEqual(t, "approved status", decided.Status, internal.BidApproved)
+
+	// The approval settles the tender, it is not closed a second time.
+	_, err = f.s.SubmitBid(ctx, late.Id, internal.DecisionApproved, alice)
+	wantErr(t, err, storage.ErrTenderNotPublished)
+	wantEqual(t, "tender status", f.tenderStatus(t, tender.Id), internal.TenderClosed)
+
+	events, err := f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.customer, EntityId: tender.Id}, internal.Page{Limit: 2})
+	wantNoErr(t, err)
+	wantActions(t, events, "tender close system", "tender edit alice")
+
+	// Tenders their responsibles close take no decisions.
+	manual := f.publishedTender(t, "Bridges")
+	bid := f.publishedBid(t, manual.Id, "Gravel")
+
+	_, err = f.s.UpdateTenderStatus(ctx, manual.Id, internal.TenderClosed, alice)
+	wantNoErr(t, err)
+
+	_, err = f.s.SubmitBid(ctx, bid.Id, internal.DecisionRejected, alice)
+	wantErr(t, err, storage.ErrTenderNotPublished)
+}
+
+func testCloseExpiredTenders(t *testing.T, f *fixture) {
+	now := time.Now().Truncate(time.Second).UTC()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	expired := f.deadlineTender(t, "Expired", &past, true)
+	later := f.deadlineTender(t, "Later", &future, true)
+	draft := f.deadlineTender(t, "Draft", &past, false)
+	open := f.deadlineTender(t, "Open", nil, true)
+
+	closed, err := f.s.CloseExpiredTenders(ctx, now)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(closed), "Expired")
+	wantEqual(t, "closed status", closed[0].Status, internal.TenderClosed)
+	wantEqual(t, "closed by", closed[0].LastEditedBy, internal.SystemActor)
+
+	got, err := f.s.GetTender(ctx, expired.Id)
+	wantNoErr(t, err)
+	wantEqual(t, "stored status", got.Status, internal.TenderClosed)
+	wantEqual(t, "stored editor", got.LastEditedBy, internal.SystemActor)
+
+	events, err := f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.customer, EntityId: expired.Id}, internal.Page{Limit: 1})
+	wantNoErr(t, err)
+	wantActions(t, events, "tender close system")
+
+	closed, err = f.s.CloseExpiredTenders(ctx, now)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(closed))
+
+	closed, err = f.s.CloseExpiredTenders(ctx, future)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(closed), "Later")
+
+	wantEqual(t, "draft status", f.tenderStatus(t, draft.Id), internal.TenderCreated)
+	wantEqual(t, "open status", f.tenderStatus(t, open.Id), internal.TenderPublished)
+	wantEqual(t, "later status", f.tenderStatus(t, later.Id), internal.TenderClosed)
+}
+
+// deadlineTender creates a tender of the customer organization with the
+// bid deadline on behalf of alice and publishes it if asked to.
+func (f *fixture) deadlineTender(t *testing.T, name string, deadline *time.Time, publish bool) internal.Tender {
+	t.Helper()
+
+	tender, err := f.s.CreateTender(ctx, internal.Tender{
+		Name:            name,
+		Description:     name + " description",
+		ServiceType:     "Construction",
+		OrganizationId:  f.customer,
+		CreatorUsername: alice,
+		BidDeadline:     deadline,
+	})
+	if err != nil {
+		t.Fatalf("create tender %s: %v", name, err)
+	}
+
+	if publish {
+		tender, err = f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, alice)
+		if err != nil {
+			t.Fatalf("publish tender %s: %v", name, err)
+		}
+	}
+
+	return tender
+}
+
+func wantDeadline(t *testing.T, tender internal.Tender, want *time.Time) {
+	t.Helper()
+
+	got := tender.BidDeadline
+	if (got == nil) != (want == nil) || got != nil && !got.Equal(*want) {
+		t.Fatalf("got bid deadline %v, want %v", got, want)
+	}
+}
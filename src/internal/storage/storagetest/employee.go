@@ -0,0 +1,79 @@
+package storagetest
+
+import (
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func testGetEmployee(t *testing.T, f *fixture) {
+	created, err := f.s.CreateEmployee(ctx, internal.Employee{Username: "frank", FirstName: "Frank", LastName: "Smith"}, erin)
+	wantNoErr(t, err)
+
+	_, err = f.s.CreateEmployee(ctx, internal.Employee{Username: "frank"}, erin)
+	wantErr(t, err, storage.ErrAlreadyExists)
+
+	e, err := f.s.GetEmployee(ctx, created.Id)
+	wantNoErr(t, err)
+	wantEqual(t, "employee", e, created)
+
+	e, err = f.s.GetEmployeeByUsername(ctx, "frank")
+	wantNoErr(t, err)
+	wantEqual(t, "employee", e, created)
+
+	_, err = f.s.GetEmployee(ctx, uuid.New())
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	_, err = f.s.GetEmployeeByUsername(ctx, mallory)
+	wantErr(t, err, storage.ErrUserNotFound)
+}
+
+func testEditEmployee(t *testing.T, f *fixture) {
+	erinEmployee, err := f.s.GetEmployeeByUsername(ctx, erin)
+	wantNoErr(t, err)
+
+	edited, err := f.s.EditEmployee(ctx, internal.Employee{Id: erinEmployee.Id, Username: "eve", FirstName: "Erin"}, erin)
+	wantNoErr(t, err)
+	wantEqual(t, "employee", edited, internal.Employee{Id: erinEmployee.Id, Username: erin, FirstName: "Erin"})
+
+	edited, err = f.s.EditEmployee(ctx, internal.Employee{Id: erinEmployee.Id, LastName: "Brown"}, erin)
+	wantNoErr(t, err)
+	wantEqual(t, "employee", edited, internal.Employee{Id: erinEmployee.Id, Username: erin, FirstName: "Erin", LastName: "Brown"})
+
+	got, err := f.s.GetEmployee(ctx, erinEmployee.Id)
+	wantNoErr(t, err)
+	wantEqual(t, "stored employee", got, edited)
+
+	_, err = f.s.EditEmployee(ctx, internal.Employee{Id: uuid.New(), FirstName: "Nobody"}, erin)
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	employees, err := f.s.GetEmployeesList(ctx, internal.Page{Limit: 2, Offset: 1})
+	wantNoErr(t, err)
+	wantNames(t, usernames(employees), bob, carol)
+}
+
+func testDeleteEmployee(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	aliceEmployee, err := f.s.GetEmployeeByUsername(ctx, alice)
+	wantNoErr(t, err)
+	bobEmployee, err := f.s.GetEmployeeByUsername(ctx, bob)
+	wantNoErr(t, err)
+
+	err = f.s.DeleteEmployee(ctx, aliceEmployee.Id, alice)
+	wantNoErr(t, err)
+
+	_, err = f.s.GetEmployee(ctx, aliceEmployee.Id)
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	// Tenders outlive the responsible who created them.
+	_, err = f.s.GetTender(ctx, tender.Id)
+	wantNoErr(t, err)
+
+	err = f.s.DeleteEmployee(ctx, bobEmployee.Id, bob)
+	wantErr(t, err, storage.ErrLastResponsible)
+
+	err = f.s.DeleteEmployee(ctx, aliceEmployee.Id, alice)
+	wantErr(t, err, storage.ErrUserNotFound)
+}
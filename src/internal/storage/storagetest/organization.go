@@ -0,0 +1,193 @@
+package storagetest
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func testOrganization(t *testing.T, f *fixture) {
+	created, err := f.s.CreateOrganization(ctx, internal.Organization{Name: "Builder", Description: "Builds roads", Type: "JSC"}, erin, erin)
+	wantNoErr(t, err)
+
+	got, err := f.s.GetOrganization(ctx, created.Id)
+	wantNoErr(t, err)
+	wantEqual(t, "organization", got, created)
+
+	ok, err := f.s.IsOrganizationResponsible(ctx, created.Id, erin)
+	wantNoErr(t, err)
+	wantEqual(t, "erin is responsible", ok, true)
+
+	_, err = f.s.CreateOrganization(ctx, internal.Organization{Name: "Ghost"}, mallory, mallory)
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	organizations, err := f.s.GetOrganizationsList(ctx, allPage)
+	wantNoErr(t, err)
+	names := make([]string, 0, len(organizations))
+	for _, o := range organizations {
+		names = append(names, o.Name)
+	}
+	wantNames(t, names, "Bidder", "Builder", "Customer")
+
+	edited, err := f.s.EditOrganization(ctx, internal.Organization{Id: created.Id, Name: "Road builder", Type: "LLC"}, erin)
+	wantNoErr(t, err)
+	wantEqual(t, "organization", edited, internal.Organization{Id: created.Id, Name: "Road builder", Description: "Builds roads", Type: "LLC"})
+
+	_, err = f.s.EditOrganization(ctx, internal.Organization{Id: uuid.New(), Name: "Nobody"}, erin)
+	wantErr(t, err, storage.ErrOrganizationNotFound)
+
+	_, err = f.s.GetOrganization(ctx, uuid.New())
+	wantErr(t, err, storage.ErrOrganizationNotFound)
+}
+
+func testDeleteOrganization(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+
+	err := f.s.DeleteOrganization(ctx, f.customer, alice)
+	wantErr(t, err, storage.ErrOrganizationInUse)
+
+	f.bid(t, tender.Id, "Asphalt")
+
+	err = f.s.DeleteOrganization(ctx, f.bidder, carol)
+	wantErr(t, err, storage.ErrOrganizationInUse)
+
+	unused, err := f.s.CreateOrganization(ctx, internal.Organization{Name: "Unused"}, erin, erin)
+	wantNoErr(t, err)
+
+	err = f.s.DeleteOrganization(ctx, unused.Id, erin)
+	wantNoErr(t, err)
+
+	_, err = f.s.GetOrganization(ctx, unused.Id)
+	wantErr(t, err, storage.ErrOrganizationNotFound)
+
+	ok, err := f.s.IsOrganizationResponsible(ctx, unused.Id, erin)
+	wantNoErr(t, err)
+	wantEqual(t, "erin is responsible", ok, false)
+
+	err = f.s.DeleteOrganization(ctx, unused.Id, erin)
+	wantErr(t, err, storage.ErrOrganizationNotFound)
+}
+
+func testResponsibles(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	err := f.s.AddOrganizationResponsible(ctx, f.customer, erin, alice)
+	wantNoErr(t, err)
+
+	err = f.s.AddOrganizationResponsible(ctx, f.customer, erin, alice)
+	wantErr(t, err, storage.ErrAlreadyExists)
+
+	err = f.s.AddOrganizationResponsible(ctx, f.customer, mallory, alice)
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	err = f.s.AddOrganizationResponsible(ctx, uuid.New(), erin, alice)
+	wantErr(t, err, storage.ErrOrganizationNotFound)
+
+	responsibles, err := f.s.GetOrganizationResponsibles(ctx, f.customer, allPage)
+	wantNoErr(t, err)
+	wantNames(t, usernames(responsibles), alice, bob, erin)
+
+	_, err = f.s.GetOrganizationResponsibles(ctx, uuid.New(), allPage)
+	wantErr(t, err, storage.ErrOrganizationNotFound)
+
+	err = f.s.RemoveOrganizationResponsible(ctx, f.customer, alice, alice)
+	wantNoErr(t, err)
+
+	// Tenders outlive the responsible who created them.
+	_, err = f.s.GetTender(ctx, tender.Id)
+	wantNoErr(t, err)
+
+	ok, err := f.s.IsOrganizationResponsible(ctx, f.customer, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "alice is responsible", ok, false)
+
+	err = f.s.RemoveOrganizationResponsible(ctx, f.customer, alice, alice)
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	err = f.s.RemoveOrganizationResponsible(ctx, f.customer, mallory, bob)
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	err = f.s.RemoveOrganizationResponsible(ctx, f.customer, bob, bob)
+	wantNoErr(t, err)
+
+	err = f.s.RemoveOrganizationResponsible(ctx, f.customer, erin, erin)
+	wantErr(t, err, storage.ErrLastResponsible)
+
+	err = f.s.RemoveOrganizationResponsible(ctx, uuid.New(), erin, erin)
+	wantErr(t, err, storage.ErrOrganizationNotFound)
+}
+
+func testOrganizationAudit(t *testing.T, f *fixture) {
+	created, err := f.s.CreateOrganization(ctx, internal.Organization{Name: "Builder"}, erin, erin)
+	wantNoErr(t, err)
+
+	_, err = f.s.EditOrganization(ctx, internal.Organization{Id: created.Id, Name: "Road builder"}, erin)
+	wantNoErr(t, err)
+
+	err = f.s.AddOrganizationResponsible(ctx, created.Id, carol, erin)
+	wantNoErr(t, err)
+
+	// Carol is responsible for two organizations now, both see her changes.
+	carolEmployee, err := f.s.GetEmployeeByUsername(ctx, carol)
+	wantNoErr(t, err)
+
+	_, err = f.s.EditEmployee(ctx, internal.Employee{Id: carolEmployee.Id, FirstName: "Carol"}, carol)
+	wantNoErr(t, err)
+
+	err = f.s.RemoveOrganizationResponsible(ctx, created.Id, carol, erin)
+	wantNoErr(t, err)
+
+	// Refused changes leave nothing behind.
+	err = f.s.RemoveOrganizationResponsible(ctx, created.Id, erin, erin)
+	wantErr(t, err, storage.ErrLastResponsible)
+
+	err = f.s.DeleteOrganization(ctx, created.Id, erin)
+	wantNoErr(t, err)
+
+	events, err := f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: created.Id}, allPage)
+	wantNoErr(t, err)
+	wantActions(t, events,
+		"organization delete erin", "organization_responsible delete erin", "employee edit carol",
+		"organization_responsible create erin", "organization edit erin",
+		"organization_responsible create erin", "organization create erin")
+
+	if len(events[0].After) != 0 {
+		t.Fatalf("deletion has an after snapshot: %s", events[0].After)
+	}
+	wantEqual(t, "deleted organization", events[0].EntityId, created.Id)
+
+	wantEqual(t, "removed responsible", events[1].EntityId, carolEmployee.Id)
+	if len(events[1].After) != 0 {
+		t.Fatalf("removal has an after snapshot: %s", events[1].After)
+	}
+
+	var before, after internal.Organization
+	wantNoErr(t, json.Unmarshal(events[4].Before, &before))
+	wantNoErr(t, json.Unmarshal(events[4].After, &after))
+	wantEqual(t, "name before editing", before.Name, "Builder")
+	wantEqual(t, "name after editing", after.Name, "Road builder")
+
+	daveEmployee, err := f.s.GetEmployeeByUsername(ctx, dave)
+	wantNoErr(t, err)
+
+	err = f.s.DeleteEmployee(ctx, daveEmployee.Id, dave)
+	wantNoErr(t, err)
+
+	events, err = f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: f.bidder, EntityType: internal.EntityEmployee}, allPage)
+	wantNoErr(t, err)
+	wantActions(t, events, "employee delete dave", "employee edit carol")
+
+	var edited internal.Employee
+	wantNoErr(t, json.Unmarshal(events[1].After, &edited))
+	wantEqual(t, "edited employee", edited.FirstName, "Carol")
+
+	// Employees responsible for nothing have their changes kept under no organization.
+	frank, err := f.s.CreateEmployee(ctx, internal.Employee{Username: "frank"}, erin)
+	wantNoErr(t, err)
+
+	events, err = f.s.GetAuditEvents(ctx, internal.AuditFilter{OrganizationId: uuid.Nil, EntityId: frank.Id}, allPage)
+	wantNoErr(t, err)
+	wantActions(t, events, "employee create erin")
+}
@@ -0,0 +1,94 @@
+package storagetest
+
+import (
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func testTenderRevisions(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	_, err := f.s.EditTender(ctx, internal.Tender{Name: "Bridges"}, tender.Id, 0, bob)
+	wantNoErr(t, err)
+
+	_, err = f.s.RollbackTender(ctx, tender.Id, 1, 0, alice)
+	wantNoErr(t, err)
+
+	revisions, err := f.s.GetTenderRevisions(ctx, tender.Id, allPage)
+	wantNoErr(t, err)
+	wantRevisions(t, revisions, alice, bob, alice)
+
+	revisions, err = f.s.GetTenderRevisions(ctx, tender.Id, internal.Page{Limit: 1, Offset: 1})
+	wantNoErr(t, err)
+	wantRevisions(t, revisions, bob)
+	wantEqual(t, "paged version", revisions[0].Version, 2)
+
+	_, err = f.s.GetTenderRevisions(ctx, uuid.New(), allPage)
+	wantErr(t, err, storage.ErrTenderNotFound)
+
+	first, err := f.s.GetTenderRevision(ctx, tender.Id, 1)
+	wantNoErr(t, err)
+	wantEqual(t, "id", first.Id, tender.Id)
+	wantEqual(t, "version", first.Version, 1)
+	wantEqual(t, "name", first.Name, "Roads")
+
+	second, err := f.s.GetTenderRevision(ctx, tender.Id, 2)
+	wantNoErr(t, err)
+	wantEqual(t, "name", second.Name, "Bridges")
+
+	_, err = f.s.GetTenderRevision(ctx, tender.Id, 10)
+	wantErr(t, err, storage.ErrVersionNotFound)
+
+	_, err = f.s.GetTenderRevision(ctx, uuid.New(), 1)
+	wantErr(t, err, storage.ErrTenderNotFound)
+}
+
+func testBidRevisions(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	bid := f.bid(t, tender.Id, "Asphalt")
+
+	_, err := f.s.EditBid(ctx, internal.Bid{Description: "Fast"}, bid.Id, 0, dave)
+	wantNoErr(t, err)
+
+	revisions, err := f.s.GetBidRevisions(ctx, bid.Id, allPage)
+	wantNoErr(t, err)
+	wantRevisions(t, revisions, carol, dave)
+
+	_, err = f.s.GetBidRevisions(ctx, uuid.New(), allPage)
+	wantErr(t, err, storage.ErrBidNotFound)
+
+	first, err := f.s.GetBidRevision(ctx, bid.Id, 1)
+	wantNoErr(t, err)
+	wantEqual(t, "id", first.Id, bid.Id)
+	wantEqual(t, "description", first.Description, "Asphalt description")
+
+	second, err := f.s.GetBidRevision(ctx, bid.Id, 2)
+	wantNoErr(t, err)
+	wantEqual(t, "description", second.Description, "Fast")
+
+	_, err = f.s.GetBidRevision(ctx, bid.Id, 0)
+	wantErr(t, err, storage.ErrVersionNotFound)
+
+	_, err = f.s.GetBidRevision(ctx, uuid.New(), 1)
+	wantErr(t, err, storage.ErrBidNotFound)
+}
+
+// wantRevisions checks the authors of consecutive revisions
+// and that each one is dated.
+func wantRevisions(t *testing.T, got []internal.Revision, authors ...string) {
+	t.Helper()
+
+	if len(got) != len(authors) {
+		t.Fatalf("got %d revisions %+v, want %d", len(got), got, len(authors))
+	}
+	for i, r := range got {
+		if r.Author != authors[i] || r.CreatedAt.IsZero() {
+			t.Fatalf("revision %d: got %+v, want author %s and a date", i, r, authors[i])
+		}
+		if i > 0 && r.Version != got[i-1].Version+1 {
+			t.Fatalf("revisions are not consecutive: %+v", got)
+		}
+	}
+}
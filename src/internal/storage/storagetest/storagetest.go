@@ -0,0 +1,275 @@
+// Package storagetest is a conformance suite shared by every storage.Storage
+// implementation. A backend runs it from its own tests:
+//
+//	func TestStorage(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storagetest.Storage { return memory.New() })
+//	}
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"slices"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+// Storage is the storage under test. Employees and organizations
+// are set up through its management methods.
+type Storage = storage.Storage
+
+// ctx is passed to every storage call, the suite never cancels it.
+var ctx = context.Background()
+
+// Factory returns an empty storage. It is called once per test case.
+type Factory func(t *testing.T) Storage
+
+// Run runs every conformance test against storages made by newStorage.
+// Test cases run one after another, so backends may share a database.
+func Run(t *testing.T, newStorage Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, f *fixture)
+	}{
+		{"GetEmployee", testGetEmployee},
+		{"EditEmployee", testEditEmployee},
+		{"DeleteEmployee", testDeleteEmployee},
+		{"Organization", testOrganization},
+		{"DeleteOrganization", testDeleteOrganization},
+		{"Responsibles", testResponsibles},
+		{"OrganizationAudit", testOrganizationAudit},
+		{"CreateTender", testCreateTender},
+		{"EditTender", testEditTender},
+		{"RollbackTender", testRollbackTender},
+		{"TenderExpectedVersion", testTenderExpectedVersion},
+		{"TenderRevisions", testTenderRevisions},
+		{"TenderTimestamps", testTenderTimestamps},
+		{"UpdateTenderStatus", testUpdateTenderStatus},
+		{"TenderVisibility", testTenderVisibility},
+		{"TendersList", testTendersList},
+		{"CreateBid", testCreateBid},
+		{"EditBid", testEditBid},
+		{"RollbackBid", testRollbackBid},
+		{"BidExpectedVersion", testBidExpectedVersion},
+		{"BidRevisions", testBidRevisions},
+		{"BidTimestamps", testBidTimestamps},
+		{"UpdateBidStatus", testUpdateBidStatus},
+		{"BidVisibility", testBidVisibility},
+		{"SubmitBid", testSubmitBid},
+		{"SubmitBidQuorum", testSubmitBidQuorum},
+		{"SubmitBidQuorumLeavers", testSubmitBidQuorumLeavers},
+		{"BidFeedback", testBidFeedback},
+		{"AuditEvents", testAuditEvents},
+		{"BidDeadline", testBidDeadline},
+		{"DecideAfterDeadline", testDecideAfterDeadline},
+		{"CloseExpiredTenders", testCloseExpiredTenders},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newFixture(t, newStorage(t)))
+		})
+	}
+}
+
+// Seeded employees. Alice and Bob are responsible for the tender
+// organization, Carol and Dave for the bidder organization, Erin for none.
+// Mallory is not an employee at all.
+const (
+	alice   = "alice"
+	bob     = "bob"
+	carol   = "carol"
+	dave    = "dave"
+	erin    = "erin"
+	mallory = "mallory"
+)
+
+type fixture struct {
+	s Storage
+
+	customer uuid.UUID
+	bidder   uuid.UUID
+}
+
+func newFixture(t *testing.T, s Storage) *fixture {
+	t.Helper()
+
+	for _, username := range []string{alice, bob, carol, dave, erin} {
+		_, err := s.CreateEmployee(ctx, internal.Employee{Username: username}, internal.SystemActor)
+		if err != nil {
+			t.Fatalf("create employee %s: %v", username, err)
+		}
+	}
+
+	f := &fixture{s: s}
+	f.customer = f.organization(t, "Customer", alice, bob)
+	f.bidder = f.organization(t, "Bidder", carol, dave)
+
+	return f
+}
+
+func (f *fixture) organization(t *testing.T, name string, responsibles ...string) uuid.UUID {
+	t.Helper()
+
+	o, err := f.s.CreateOrganization(ctx, internal.Organization{Name: name, Type: "LLC"}, responsibles[0], responsibles...)
+	if err != nil {
+		t.Fatalf("create organization %s: %v", name, err)
+	}
+
+	return o.Id
+}
+
+// tender creates a tender of the customer organization on behalf of alice.
+func (f *fixture) tender(t *testing.T, name string) internal.Tender {
+	t.Helper()
+
+	tender, err := f.s.CreateTender(ctx, internal.Tender{
+		Name:            name,
+		Description:     name + " description",
+		ServiceType:     "Construction",
+		OrganizationId:  f.customer,
+		CreatorUsername: alice,
+	})
+	if err != nil {
+		t.Fatalf("create tender %s: %v", name, err)
+	}
+
+	return tender
+}
+
+func (f *fixture) publishedTender(t *testing.T, name string) internal.Tender {
+	t.Helper()
+
+	tender := f.tender(t, name)
+
+	tender, err := f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, alice)
+	if err != nil {
+		t.Fatalf("publish tender %s: %v", name, err)
+	}
+
+	return tender
+}
+
+// bid creates a bid of the bidder organization on behalf of carol.
+func (f *fixture) bid(t *testing.T, tenderId uuid.UUID, name string) internal.Bid {
+	t.Helper()
+
+	bid, err := f.s.CreateBid(ctx, internal.Bid{
+		Name:            name,
+		Description:     name + " description",
+		TenderId:        tenderId,
+		OrganizationId:  f.bidder,
+		CreatorUsername: carol,
+	})
+	if err != nil {
+		t.Fatalf("create bid %s: %v", name, err)
+	}
+
+	return bid
+}
+
+func (f *fixture) publishedBid(t *testing.T, tenderId uuid.UUID, name string) internal.Bid {
+	t.Helper()
+
+	bid := f.bid(t, tenderId, name)
+
+	bid, err := f.s.UpdateBidStatus(ctx, bid.Id, internal.BidPublished, carol)
+	if err != nil {
+		t.Fatalf("publish bid %s: %v", name, err)
+	}
+
+	return bid
+}
+
+func (f *fixture) tenderStatus(t *testing.T, tenderId uuid.UUID) string {
+	t.Helper()
+
+	tender, err := f.s.GetTender(ctx, tenderId)
+	if err != nil {
+		t.Fatalf("get tender: %v", err)
+	}
+
+	return tender.Status
+}
+
+func (f *fixture) bidStatus(t *testing.T, bidId uuid.UUID) string {
+	t.Helper()
+
+	bid, err := f.s.GetBid(ctx, bidId)
+	if err != nil {
+		t.Fatalf("get bid: %v", err)
+	}
+
+	return bid.Status
+}
+
+// check evaluates the policy with the storage answering the facts.
+func (f *fixture) check(p authz.Policy, username string, res authz.Resource) error {
+	return authz.Check(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), f.s, p, username, res)
+}
+
+var allPage = internal.Page{Limit: 50}
+
+func wantErr(t *testing.T, err, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+}
+
+func wantNoErr(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func wantEqual[T comparable](t *testing.T, what string, got, want T) {
+	t.Helper()
+
+	if got != want {
+		t.Fatalf("%s: got %v, want %v", what, got, want)
+	}
+}
+
+func tenderNames(tenders []internal.Tender) []string {
+	names := make([]string, 0, len(tenders))
+	for _, t := range tenders {
+		names = append(names, t.Name)
+	}
+
+	return names
+}
+
+func usernames(employees []internal.Employee) []string {
+	names := make([]string, 0, len(employees))
+	for _, e := range employees {
+		names = append(names, e.Username)
+	}
+
+	return names
+}
+
+func bidNames(bids []internal.Bid) []string {
+	names := make([]string, 0, len(bids))
+	for _, b := range bids {
+		names = append(names, b.Name)
+	}
+
+	return names
+}
+
+func wantNames(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
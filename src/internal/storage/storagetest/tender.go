@@ -0,0 +1,213 @@
+package storagetest
+
+import (
+	"github.com/google/uuid"
+	"tender-app-backend/src/internal"
+	"tender-app-backend/src/internal/authz"
+	"tender-app-backend/src/internal/storage"
+	"testing"
+)
+
+func testCreateTender(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	if tender.Id == uuid.Nil {
+		t.Fatal("created tender has no id")
+	}
+	wantEqual(t, "version", tender.Version, 1)
+	wantEqual(t, "status", tender.Status, internal.TenderCreated)
+	wantEqual(t, "stored status", f.tenderStatus(t, tender.Id), internal.TenderCreated)
+
+	_, err := f.s.CreateTender(ctx, internal.Tender{Name: "Roads", OrganizationId: f.customer, CreatorUsername: erin})
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	_, err = f.s.CreateTender(ctx, internal.Tender{Name: "Roads", OrganizationId: f.customer, CreatorUsername: carol})
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	_, err = f.s.CreateTender(ctx, internal.Tender{Name: "Roads", OrganizationId: f.customer, CreatorUsername: mallory})
+	wantErr(t, err, storage.ErrUserNotFound)
+}
+
+func testEditTender(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	edited, err := f.s.EditTender(ctx, internal.Tender{Name: "Bridges"}, tender.Id, 0, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "id", edited.Id, tender.Id)
+	wantEqual(t, "version", edited.Version, 2)
+	wantEqual(t, "name", edited.Name, "Bridges")
+	wantEqual(t, "description", edited.Description, tender.Description)
+	wantEqual(t, "service type", edited.ServiceType, tender.ServiceType)
+
+	edited, err = f.s.EditTender(ctx, internal.Tender{Description: "Steel bridges", ServiceType: "Delivery"}, tender.Id, 0, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "version", edited.Version, 3)
+	wantEqual(t, "name", edited.Name, "Bridges")
+	wantEqual(t, "description", edited.Description, "Steel bridges")
+	wantEqual(t, "service type", edited.ServiceType, "Delivery")
+
+	tenders, err := f.s.GetUserTendersList(ctx, internal.Viewer{Username: alice}, nil, allPage)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders), "Bridges")
+	wantEqual(t, "listed version", tenders[0].Version, 3)
+
+	_, err = f.s.EditTender(ctx, internal.Tender{Name: "Tunnels"}, uuid.New(), 0, alice)
+	wantErr(t, err, storage.ErrTenderNotFound)
+}
+
+func testRollbackTender(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	_, err := f.s.EditTender(ctx, internal.Tender{Name: "Bridges"}, tender.Id, 0, alice)
+	wantNoErr(t, err)
+
+	_, err = f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, alice)
+	wantNoErr(t, err)
+
+	// Rollback does not rewrite history, it adds a new version
+	// with the contents of the old one and keeps the current status.
+	rolledBack, err := f.s.RollbackTender(ctx, tender.Id, 1, 0, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "id", rolledBack.Id, tender.Id)
+	wantEqual(t, "version", rolledBack.Version, 3)
+	wantEqual(t, "name", rolledBack.Name, "Roads")
+	wantEqual(t, "status", rolledBack.Status, internal.TenderPublished)
+
+	rolledBack, err = f.s.RollbackTender(ctx, tender.Id, 2, 0, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "version", rolledBack.Version, 4)
+	wantEqual(t, "name", rolledBack.Name, "Bridges")
+
+	_, err = f.s.RollbackTender(ctx, tender.Id, 10, 0, alice)
+	wantErr(t, err, storage.ErrTenderNotFound)
+
+	_, err = f.s.RollbackTender(ctx, uuid.New(), 1, 0, alice)
+	wantErr(t, err, storage.ErrTenderNotFound)
+}
+
+func testTenderExpectedVersion(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	edited, err := f.s.EditTender(ctx, internal.Tender{Name: "Bridges"}, tender.Id, 1, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "version", edited.Version, 2)
+
+	// A second edit based on the same version lost the race.
+	_, err = f.s.EditTender(ctx, internal.Tender{Name: "Tunnels"}, tender.Id, 1, alice)
+	wantErr(t, err, storage.ErrVersionConflict)
+
+	_, err = f.s.RollbackTender(ctx, tender.Id, 1, 1, alice)
+	wantErr(t, err, storage.ErrVersionConflict)
+
+	rolledBack, err := f.s.RollbackTender(ctx, tender.Id, 1, 2, alice)
+	wantNoErr(t, err)
+	wantEqual(t, "version", rolledBack.Version, 3)
+	wantEqual(t, "name", rolledBack.Name, "Roads")
+}
+
+func testUpdateTenderStatus(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	_, err := f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, carol)
+	wantErr(t, err, storage.ErrOrgRespNotFound)
+
+	_, err = f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, mallory)
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	// Any responsible of the organization may change the status.
+	updated, err := f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, bob)
+	wantNoErr(t, err)
+	wantEqual(t, "status", updated.Status, internal.TenderPublished)
+	wantEqual(t, "version", updated.Version, 1)
+	wantEqual(t, "stored status", f.tenderStatus(t, tender.Id), internal.TenderPublished)
+
+	_, err = f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderCreated, alice)
+	wantErr(t, err, storage.ErrInvalidTransition)
+
+	_, err = f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderClosed, alice)
+	wantNoErr(t, err)
+
+	_, err = f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, alice)
+	wantErr(t, err, storage.ErrInvalidTransition)
+
+	_, err = f.s.UpdateTenderStatus(ctx, uuid.New(), internal.TenderPublished, alice)
+	wantErr(t, err, storage.ErrTenderNotFound)
+}
+
+func testTenderVisibility(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	err := f.check(authz.ViewTender, carol, authz.Tender(tender))
+	wantErr(t, err, authz.ErrDenied)
+
+	err = f.check(authz.ViewTender, "", authz.Tender(tender))
+	wantErr(t, err, authz.ErrDenied)
+
+	err = f.check(authz.ViewTender, mallory, authz.Tender(tender))
+	wantErr(t, err, storage.ErrUserNotFound)
+
+	err = f.check(authz.ViewTender, bob, authz.Tender(tender))
+	wantNoErr(t, err)
+
+	_, err = f.s.GetTender(ctx, uuid.New())
+	wantErr(t, err, storage.ErrTenderNotFound)
+
+	tenders, err := f.s.GetTendersList(ctx, internal.Viewer{Username: carol}, nil, allPage)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders))
+
+	tender, err = f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, alice)
+	wantNoErr(t, err)
+
+	err = f.check(authz.ViewTender, "", authz.Tender(tender))
+	wantNoErr(t, err)
+	wantEqual(t, "status", f.tenderStatus(t, tender.Id), internal.TenderPublished)
+
+	tenders, err = f.s.GetTendersList(ctx, internal.Viewer{Username: carol}, nil, allPage)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders), "Roads")
+}
+
+func testTendersList(t *testing.T, f *fixture) {
+	for _, name := range []string{"C", "A", "E", "B", "D"} {
+		f.tender(t, name)
+	}
+
+	_, err := f.s.CreateTender(ctx, internal.Tender{
+		Name:            "F",
+		ServiceType:     "Delivery",
+		OrganizationId:  f.customer,
+		CreatorUsername: bob,
+	})
+	wantNoErr(t, err)
+
+	alicev := internal.Viewer{Username: alice}
+
+	tenders, err := f.s.GetTendersList(ctx, alicev, nil, allPage)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders), "A", "B", "C", "D", "E", "F")
+
+	tenders, err = f.s.GetTendersList(ctx, alicev, nil, internal.Page{Limit: 2, Offset: 1})
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders), "B", "C")
+
+	tenders, err = f.s.GetTendersList(ctx, alicev, nil, internal.Page{Limit: 5, Offset: 10})
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders))
+
+	tenders, err = f.s.GetTendersList(ctx, alicev, []string{"Delivery"}, allPage)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders), "F")
+
+	tenders, err = f.s.GetTendersList(ctx, alicev, []string{"Delivery", "Construction"}, internal.Page{Limit: 3})
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders), "A", "B", "C")
+
+	tenders, err = f.s.GetUserTendersList(ctx, internal.Viewer{Username: bob}, nil, allPage)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders), "F")
+
+	tenders, err = f.s.GetUserTendersList(ctx, alicev, []string{"Delivery"}, allPage)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders))
+}
@@ -0,0 +1,92 @@
+package storagetest
+
+import (
+	"tender-app-backend/src/internal"
+	"testing"
+	"time"
+)
+
+func testTenderTimestamps(t *testing.T, f *fixture) {
+	tender := f.tender(t, "Roads")
+
+	if tender.CreatedAt.IsZero() {
+		t.Fatal("created tender has no creation time")
+	}
+	wantSameTime(t, "updated at", tender.UpdatedAt, tender.CreatedAt)
+	wantEqual(t, "last edited by", tender.LastEditedBy, alice)
+
+	edited, err := f.s.EditTender(ctx, internal.Tender{Name: "Bridges"}, tender.Id, 0, bob)
+	wantNoErr(t, err)
+	wantSameTime(t, "created at", edited.CreatedAt, tender.CreatedAt)
+	wantNotBefore(t, "updated at", edited.UpdatedAt, tender.UpdatedAt)
+	wantEqual(t, "last edited by", edited.LastEditedBy, bob)
+
+	published, err := f.s.UpdateTenderStatus(ctx, tender.Id, internal.TenderPublished, alice)
+	wantNoErr(t, err)
+	wantNotBefore(t, "updated at", published.UpdatedAt, edited.UpdatedAt)
+	wantEqual(t, "last edited by", published.LastEditedBy, alice)
+
+	got, err := f.s.GetTender(ctx, tender.Id)
+	wantNoErr(t, err)
+	wantSameTime(t, "stored created at", got.CreatedAt, tender.CreatedAt)
+	wantSameTime(t, "stored updated at", got.UpdatedAt, published.UpdatedAt)
+	wantEqual(t, "stored last edited by", got.LastEditedBy, alice)
+
+	tenders, err := f.s.GetTendersList(ctx, internal.Viewer{Username: erin}, nil, allPage)
+	wantNoErr(t, err)
+	wantNames(t, tenderNames(tenders), "Bridges")
+	wantSameTime(t, "listed created at", tenders[0].CreatedAt, tender.CreatedAt)
+	wantEqual(t, "listed last edited by", tenders[0].LastEditedBy, alice)
+
+	second, err := f.s.GetTenderRevision(ctx, tender.Id, 2)
+	wantNoErr(t, err)
+	wantEqual(t, "revision last edited by", second.LastEditedBy, bob)
+}
+
+func testBidTimestamps(t *testing.T, f *fixture) {
+	tender := f.publishedTender(t, "Roads")
+	bid := f.bid(t, tender.Id, "Asphalt")
+
+	if bid.CreatedAt.IsZero() {
+		t.Fatal("created bid has no creation time")
+	}
+	wantSameTime(t, "updated at", bid.UpdatedAt, bid.CreatedAt)
+	wantEqual(t, "last edited by", bid.LastEditedBy, carol)
+
+	edited, err := f.s.EditBid(ctx, internal.Bid{Description: "Fast"}, bid.Id, 0, dave)
+	wantNoErr(t, err)
+	wantSameTime(t, "created at", edited.CreatedAt, bid.CreatedAt)
+	wantNotBefore(t, "updated at", edited.UpdatedAt, bid.UpdatedAt)
+	wantEqual(t, "last edited by", edited.LastEditedBy, dave)
+
+	published, err := f.s.UpdateBidStatus(ctx, bid.Id, internal.BidPublished, carol)
+	wantNoErr(t, err)
+	wantNotBefore(t, "updated at", published.UpdatedAt, edited.UpdatedAt)
+	wantEqual(t, "last edited by", published.LastEditedBy, carol)
+
+	rejected, err := f.s.SubmitBid(ctx, bid.Id, internal.DecisionRejected, bob)
+	wantNoErr(t, err)
+	wantSameTime(t, "created at after decision", rejected.CreatedAt, bid.CreatedAt)
+	wantEqual(t, "last edited by after decision", rejected.LastEditedBy, bob)
+
+	bids, err := f.s.GetUserBidsList(ctx, internal.Viewer{Username: carol}, allPage)
+	wantNoErr(t, err)
+	wantNames(t, bidNames(bids), "Asphalt")
+	wantEqual(t, "listed last edited by", bids[0].LastEditedBy, bob)
+}
+
+func wantSameTime(t *testing.T, what string, got, want time.Time) {
+	t.Helper()
+
+	if !got.Equal(want) {
+		t.Fatalf("%s: got %v, want %v", what, got, want)
+	}
+}
+
+func wantNotBefore(t *testing.T, what string, got, want time.Time) {
+	t.Helper()
+
+	if got.Before(want) {
+		t.Fatalf("%s: got %v, want %v or later", what, got, want)
+	}
+}
@@ -1,12 +1,68 @@
 package internal
 
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
 type Tender struct {
-	Id              int    `json:"id,omitempty"`
-	Name            string `json:"name,omitempty"`
-	Description     string `json:"description,omitempty"`
-	ServiceType     string `json:"serviceType,omitempty"`
-	Status          string `json:"status,omitempty"`
-	OrganizationId  int    `json:"organizationId,omitempty" validate:"required"`
-	CreatorUsername string `json:"creatorUsername,omitempty" validate:"required"`
-	Version         int    `json:"version,omitempty"`
+	Id              uuid.UUID `json:"id,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Description     string    `json:"description,omitempty"`
+	ServiceType     string    `json:"serviceType,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	OrganizationId  uuid.UUID `json:"organizationId,omitempty" validate:"required"`
+	CreatorUsername string    `json:"creatorUsername,omitempty" validate:"required"`
+	Version         int       `json:"version,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	LastEditedBy    string    `json:"lastEditedBy,omitempty"`
+	// BidDeadline is when the tender stops taking bids, nil if it never does.
+	BidDeadline *time.Time `json:"bidDeadline,omitempty"`
+}
+
+const (
+	TenderCreated   = "CREATED"
+	TenderPublished = "PUBLISHED"
+	TenderClosed    = "CLOSED"
+)
+
+// tenderTransitions lists the statuses a tender can move to from each status.
+var tenderTransitions = map[string][]string{
+	TenderCreated:   {TenderPublished, TenderClosed},
+	TenderPublished: {TenderClosed},
+}
+
+func CanChangeTenderStatus(from, to string) bool {
+	for _, s := range tenderTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AcceptsBids reports whether bids for the tender may still be created,
+// edited and published at now.
+func (t Tender) AcceptsBids(now time.Time) bool {
+	return t.BidDeadline == nil || now.Before(*t.BidDeadline)
+}
+
+// DiffTenders lists the editable fields that differ between two versions
+// of a tender.
+func DiffTenders(from, to Tender) []Change {
+	return changes{}.
+		add("name", from.Name, to.Name).
+		add("description", from.Description, to.Description).
+		add("serviceType", from.ServiceType, to.ServiceType).
+		add("bidDeadline", formatDeadline(from.BidDeadline), formatDeadline(to.BidDeadline))
+}
+
+func formatDeadline(deadline *time.Time) string {
+	if deadline == nil {
+		return ""
+	}
+
+	return deadline.UTC().Format(time.RFC3339)
 }
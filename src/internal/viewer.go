@@ -0,0 +1,7 @@
+package internal
+
+// Viewer is the user on whose behalf tenders and bids are read.
+// A zero Viewer is anonymous and only sees published tenders.
+type Viewer struct {
+	Username string
+}